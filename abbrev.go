@@ -0,0 +1,205 @@
+package calcdate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TZAbbrevPolicy selects how an ambiguous timezone abbreviation such as
+// "IST" (India, Irish or Israel Standard Time) is resolved.
+type TZAbbrevPolicy string
+
+// Abbreviation policies. Any region name listed in AbbrevRegions is also a
+// valid policy: it prefers the meaning used in that region.
+const (
+	// TZAbbrevFirst picks the most common meaning (IST = India).
+	TZAbbrevFirst TZAbbrevPolicy = "first"
+	// TZAbbrevStrict rejects ambiguous abbreviations.
+	TZAbbrevStrict TZAbbrevPolicy = "strict"
+)
+
+// AbbrevRegions lists the regions usable as an abbreviation policy.
+var AbbrevRegions = []string{"america", "europe", "asia", "middle-east", "africa", "australia", "pacific"}
+
+// abbrevMeaning is one interpretation of an abbreviation: either a fixed
+// offset (for abbreviations that name a standard or daylight time, like
+// "PDT") or a zone: a representative one for generic names like "PT", or the
+// tzdata zone of the same name for "CET", "EST"... which time.LoadLocation
+// resolves, with summer time where the zone observes it.
+type abbrevMeaning struct {
+	offset      int // seconds east of UTC, used when zone is empty
+	zone        string
+	region      string
+	description string
+}
+
+func fixedAbbrev(hours, minutes int, region, description string) abbrevMeaning {
+	offset := hours*SecondsPerHour + minutes*SecondsPerMinute
+	if hours < 0 {
+		offset = hours*SecondsPerHour - minutes*SecondsPerMinute
+	}
+	return abbrevMeaning{offset: offset, region: region, description: description}
+}
+
+func zoneAbbrev(name, region, description string) abbrevMeaning {
+	return abbrevMeaning{zone: name, region: region, description: description}
+}
+
+// timezoneAbbreviations maps upper-case abbreviations to their meanings,
+// the most common meaning first.
+//
+//nolint:mnd // lookup table
+var timezoneAbbreviations = map[string][]abbrevMeaning{
+	"UTC":  {zoneAbbrev("UTC", "", "Coordinated Universal Time")},
+	"GMT":  {zoneAbbrev("GMT", "europe", "Greenwich Mean Time")},
+	"WET":  {zoneAbbrev("WET", "europe", "Western European Time")},
+	"WEST": {fixedAbbrev(1, 0, "europe", "Western European Summer Time")},
+	"CET":  {zoneAbbrev("CET", "europe", "Central European Time")},
+	"CEST": {fixedAbbrev(2, 0, "europe", "Central European Summer Time")},
+	"MET":  {zoneAbbrev("MET", "europe", "Middle European Time")},
+	"EET":  {zoneAbbrev("EET", "europe", "Eastern European Time")},
+	"EEST": {fixedAbbrev(3, 0, "europe", "Eastern European Summer Time")},
+	"MSK":  {fixedAbbrev(3, 0, "europe", "Moscow Time")},
+	"BST": {
+		fixedAbbrev(1, 0, "europe", "British Summer Time"),
+		fixedAbbrev(6, 0, "asia", "Bangladesh Standard Time"),
+	},
+	"IST": {
+		fixedAbbrev(5, 30, "asia", "India Standard Time"),
+		fixedAbbrev(1, 0, "europe", "Irish Standard Time"),
+		fixedAbbrev(2, 0, "middle-east", "Israel Standard Time"),
+	},
+	"IDT": {fixedAbbrev(3, 0, "middle-east", "Israel Daylight Time")},
+	"EST": {zoneAbbrev("EST", "america", "Eastern Standard Time")},
+	"EDT": {fixedAbbrev(-4, 0, "america", "Eastern Daylight Time")},
+	"CST": {
+		fixedAbbrev(-6, 0, "america", "Central Standard Time"),
+		fixedAbbrev(8, 0, "asia", "China Standard Time"),
+	},
+	"CDT": {fixedAbbrev(-5, 0, "america", "Central Daylight Time")},
+	"MST": {
+		zoneAbbrev("MST", "america", "Mountain Standard Time"),
+		fixedAbbrev(8, 0, "asia", "Malaysia Standard Time"),
+	},
+	"MDT": {fixedAbbrev(-6, 0, "america", "Mountain Daylight Time")},
+	"PST": {
+		fixedAbbrev(-8, 0, "america", "Pacific Standard Time"),
+		fixedAbbrev(8, 0, "asia", "Philippine Standard Time"),
+	},
+	"PDT":  {fixedAbbrev(-7, 0, "america", "Pacific Daylight Time")},
+	"AKST": {fixedAbbrev(-9, 0, "america", "Alaska Standard Time")},
+	"AKDT": {fixedAbbrev(-8, 0, "america", "Alaska Daylight Time")},
+	"HST":  {zoneAbbrev("HST", "pacific", "Hawaii Standard Time")},
+	"AST": {
+		fixedAbbrev(-4, 0, "america", "Atlantic Standard Time"),
+		fixedAbbrev(3, 0, "middle-east", "Arabia Standard Time"),
+	},
+	"ADT": {fixedAbbrev(-3, 0, "america", "Atlantic Daylight Time")},
+	"NST": {fixedAbbrev(-3, 30, "america", "Newfoundland Standard Time")},
+	"NDT": {fixedAbbrev(-2, 30, "america", "Newfoundland Daylight Time")},
+	"BRT": {fixedAbbrev(-3, 0, "america", "Brasilia Time")},
+	"ART": {fixedAbbrev(-3, 0, "america", "Argentina Time")},
+	"GST": {fixedAbbrev(4, 0, "middle-east", "Gulf Standard Time")},
+	"PKT": {fixedAbbrev(5, 0, "asia", "Pakistan Standard Time")},
+	"NPT": {fixedAbbrev(5, 45, "asia", "Nepal Time")},
+	"ICT": {fixedAbbrev(7, 0, "asia", "Indochina Time")},
+	"WIB": {fixedAbbrev(7, 0, "asia", "Western Indonesia Time")},
+	"HKT": {fixedAbbrev(8, 0, "asia", "Hong Kong Time")},
+	"SGT": {fixedAbbrev(8, 0, "asia", "Singapore Time")},
+	"PHT": {fixedAbbrev(8, 0, "asia", "Philippine Time")},
+	"JST": {fixedAbbrev(9, 0, "asia", "Japan Standard Time")},
+	"KST": {fixedAbbrev(9, 0, "asia", "Korea Standard Time")},
+	"SST": {
+		fixedAbbrev(-11, 0, "pacific", "Samoa Standard Time"),
+		fixedAbbrev(8, 0, "asia", "Singapore Standard Time"),
+	},
+	"AWST": {fixedAbbrev(8, 0, "australia", "Australian Western Standard Time")},
+	"ACST": {fixedAbbrev(9, 30, "australia", "Australian Central Standard Time")},
+	"ACDT": {fixedAbbrev(10, 30, "australia", "Australian Central Daylight Time")},
+	"AEST": {fixedAbbrev(10, 0, "australia", "Australian Eastern Standard Time")},
+	"AEDT": {fixedAbbrev(11, 0, "australia", "Australian Eastern Daylight Time")},
+	"NZST": {fixedAbbrev(12, 0, "pacific", "New Zealand Standard Time")},
+	"NZDT": {fixedAbbrev(13, 0, "pacific", "New Zealand Daylight Time")},
+	"WAT":  {fixedAbbrev(1, 0, "africa", "West Africa Time")},
+	"CAT":  {fixedAbbrev(2, 0, "africa", "Central Africa Time")},
+	"SAST": {fixedAbbrev(2, 0, "africa", "South Africa Standard Time")},
+	"EAT":  {fixedAbbrev(3, 0, "africa", "East Africa Time")},
+	// Generic names follow daylight saving time through a representative zone
+	"ET": {zoneAbbrev("America/New_York", "america", "Eastern Time")},
+	"CT": {zoneAbbrev("America/Chicago", "america", "Central Time")},
+	"MT": {zoneAbbrev("America/Denver", "america", "Mountain Time")},
+	"PT": {zoneAbbrev("America/Los_Angeles", "america", "Pacific Time")},
+}
+
+// ParseTZAbbrevPolicy validates a policy name: "first", "strict" or a region.
+func ParseTZAbbrevPolicy(name string) (TZAbbrevPolicy, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch TZAbbrevPolicy(name) {
+	case "", TZAbbrevFirst:
+		return TZAbbrevFirst, nil
+	case TZAbbrevStrict:
+		return TZAbbrevStrict, nil
+	}
+	for _, region := range AbbrevRegions {
+		if name == region {
+			return TZAbbrevPolicy(name), nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s (use first, strict or one of %s)",
+		ErrUnknownTZAbbrevPolicy, name, strings.Join(AbbrevRegions, ", "))
+}
+
+// IsTimezoneAbbreviation reports whether name is a known abbreviation.
+func IsTimezoneAbbreviation(name string) bool {
+	_, ok := timezoneAbbreviations[strings.ToUpper(name)]
+	return ok
+}
+
+// ResolveTimezoneAbbreviation returns the location for an abbreviation such
+// as "PDT" or "IST". Abbreviations that are tzdata zones ("CET", "EST") load
+// that zone; the others naming a standard or daylight time resolve to a fixed
+// offset named after the abbreviation; generic ones ("PT") resolve to a
+// representative zone. Ambiguous abbreviations are resolved by policy.
+func ResolveTimezoneAbbreviation(name string, policy TZAbbrevPolicy) (*time.Location, error) {
+	abbrev := strings.ToUpper(name)
+	meanings, ok := timezoneAbbreviations[abbrev]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTimezone, name)
+	}
+
+	meaning, err := pickMeaning(abbrev, meanings, policy)
+	if err != nil {
+		return nil, err
+	}
+	if meaning.zone != "" {
+		return LoadTimezone(meaning.zone)
+	}
+	return time.FixedZone(abbrev, meaning.offset), nil
+}
+
+func pickMeaning(abbrev string, meanings []abbrevMeaning, policy TZAbbrevPolicy) (abbrevMeaning, error) {
+	if len(meanings) == 1 {
+		return meanings[0], nil
+	}
+
+	switch policy {
+	case "", TZAbbrevFirst:
+		return meanings[0], nil
+	case TZAbbrevStrict:
+		descriptions := make([]string, len(meanings))
+		for i, m := range meanings {
+			descriptions[i] = m.description
+		}
+		return abbrevMeaning{}, fmt.Errorf("%w: %s can be %s",
+			ErrAmbiguousTimezoneAbbrev, abbrev, strings.Join(descriptions, ", "))
+	default:
+		// Prefer the meaning of the policy region, otherwise the most common one
+		for _, m := range meanings {
+			if m.region == string(policy) {
+				return m, nil
+			}
+		}
+		return meanings[0], nil
+	}
+}
@@ -0,0 +1,101 @@
+package calcdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveTimezoneAbbreviation(t *testing.T) {
+	testCases := []struct {
+		abbrev string
+		policy TZAbbrevPolicy
+		offset int
+	}{
+		{abbrev: "PDT", policy: TZAbbrevFirst, offset: -7 * SecondsPerHour},
+		{abbrev: "cest", policy: TZAbbrevFirst, offset: 2 * SecondsPerHour},
+		{abbrev: "NST", policy: TZAbbrevFirst, offset: -(3*SecondsPerHour + 30*SecondsPerMinute)},
+		{abbrev: "IST", policy: TZAbbrevFirst, offset: 5*SecondsPerHour + 30*SecondsPerMinute},
+		{abbrev: "IST", policy: "europe", offset: 1 * SecondsPerHour},
+		{abbrev: "IST", policy: "middle-east", offset: 2 * SecondsPerHour},
+		{abbrev: "IST", policy: "africa", offset: 5*SecondsPerHour + 30*SecondsPerMinute},
+		{abbrev: "CST", policy: "asia", offset: 8 * SecondsPerHour},
+		{abbrev: "JST", policy: TZAbbrevStrict, offset: 9 * SecondsPerHour},
+		{abbrev: "CET", policy: TZAbbrevFirst, offset: 1 * SecondsPerHour},
+		{abbrev: "MST", policy: TZAbbrevFirst, offset: -7 * SecondsPerHour},
+		{abbrev: "MST", policy: "asia", offset: 8 * SecondsPerHour},
+	}
+
+	at := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	for _, tc := range testCases {
+		t.Run(tc.abbrev+"/"+string(tc.policy), func(t *testing.T) {
+			loc, err := ResolveTimezoneAbbreviation(tc.abbrev, tc.policy)
+			require.NoError(t, err)
+			_, offset := at.In(loc).Zone()
+			assert.Equal(t, tc.offset, offset)
+		})
+	}
+
+	_, err := ResolveTimezoneAbbreviation("IST", TZAbbrevStrict)
+	require.ErrorIs(t, err, ErrAmbiguousTimezoneAbbrev)
+
+	_, err = ResolveTimezoneAbbreviation("XYZ", TZAbbrevFirst)
+	require.ErrorIs(t, err, ErrInvalidTimezone)
+
+	// Generic names follow daylight saving time
+	loc, err := ResolveTimezoneAbbreviation("PT", TZAbbrevFirst)
+	require.NoError(t, err)
+	assert.Equal(t, "America/Los_Angeles", loc.String())
+}
+
+func TestParseTZAbbrevPolicy(t *testing.T) {
+	policy, err := ParseTZAbbrevPolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, TZAbbrevFirst, policy)
+
+	policy, err = ParseTZAbbrevPolicy("Europe")
+	require.NoError(t, err)
+	assert.Equal(t, TZAbbrevPolicy("europe"), policy)
+
+	_, err = ParseTZAbbrevPolicy("mars")
+	require.ErrorIs(t, err, ErrUnknownTZAbbrevPolicy)
+}
+
+func TestAbbreviationLiterals(t *testing.T) {
+	testCases := []struct {
+		input    string
+		policy   TZAbbrevPolicy
+		expected time.Time
+	}{
+		{input: "2024-01-15 10:00 PDT", expected: time.Date(2024, 1, 15, 17, 0, 0, 0, time.UTC)},
+		{input: "2024-01-15 10:00:00 CEST", expected: time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC)},
+		// CET is a tzdata zone and observes summer time
+		{input: "2024-07-15 10:00:00 CET", expected: time.Date(2024, 7, 15, 8, 0, 0, 0, time.UTC)},
+		{input: "2024-01-15T10:00:00 IST", expected: time.Date(2024, 1, 15, 4, 30, 0, 0, time.UTC)},
+		{input: "2024-01-15 10:00 IST", policy: "europe", expected: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)},
+		{input: "2024-07-15 10:00 PT", expected: time.Date(2024, 7, 15, 17, 0, 0, 0, time.UTC)},
+		{input: "2024-01-15 10:00 PDT | +1d", expected: time.Date(2024, 1, 16, 17, 0, 0, 0, time.UTC)},
+		{input: "2024-01-15 10:00:00 Europe/Paris", expected: time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)},
+		{input: "2024-07-15T10:00 America/New_York | +1h", expected: time.Date(2024, 7, 15, 15, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			node, err := NewExprParser("").Parse(tc.input)
+			require.NoError(t, err)
+			result, err := node.Evaluate(&EvalContext{Timezone: time.UTC, AbbrevPolicy: tc.policy})
+			require.NoError(t, err)
+			assert.True(t, tc.expected.Equal(result), "got %s", result)
+		})
+	}
+
+	node, err := NewExprParser("").Parse("2024-01-15 10:00 IST")
+	require.NoError(t, err)
+	_, err = node.Evaluate(&EvalContext{Timezone: time.UTC, AbbrevPolicy: TZAbbrevStrict})
+	require.ErrorIs(t, err, ErrAmbiguousTimezoneAbbrev)
+
+	_, err = NewExprParser("").Parse("2024-01-15 10:00:00 Paris")
+	require.ErrorIs(t, err, ErrUnexpectedToken)
+}
@@ -0,0 +1,53 @@
+package calcdate
+
+import (
+	"sort"
+	"time"
+)
+
+// Bucket is an iteration interval with the number of timestamps in it.
+type Bucket struct {
+	IterationResult
+	Count int
+}
+
+// Histogram counts timestamps in the intervals of an iteration, from their
+// begin time included to their end time excluded, so that bucket boundaries
+// follow those of the iterator (months, DST days, fiscal quarters...).
+type Histogram struct {
+	Buckets []Bucket
+	// Outside counts the timestamps in no bucket.
+	Outside int
+}
+
+// NewHistogram returns empty buckets over rows, sorted by begin time as
+// iterations return them.
+func NewHistogram(rows []IterationResult) *Histogram {
+	buckets := make([]Bucket, len(rows))
+	for i, row := range rows {
+		buckets[i].IterationResult = row
+	}
+	return &Histogram{Buckets: buckets}
+}
+
+// Add counts t in its bucket, and returns false when it is in none.
+func (h *Histogram) Add(t time.Time) bool {
+	i := sort.Search(len(h.Buckets), func(i int) bool {
+		return h.Buckets[i].EndTime.After(t)
+	})
+	if i == len(h.Buckets) || t.Before(h.Buckets[i].BeginTime) {
+		h.Outside++
+		return false
+	}
+	h.Buckets[i].Count++
+	return true
+}
+
+// Max returns the largest bucket count.
+func (h *Histogram) Max() int {
+	highest := 0
+	for _, bucket := range h.Buckets {
+		highest = max(highest, bucket.Count)
+	}
+	return highest
+}
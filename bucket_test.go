@@ -0,0 +1,38 @@
+package calcdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogram(t *testing.T) {
+	paris := mustLoad(t, "Europe/Paris")
+	start := time.Date(2025, 3, 29, 0, 0, 0, 0, paris)
+	rows, err := IterateWithSpecialIntervalContext(start, start.AddDate(0, 0, 3), "1d", nil, &EvalContext{Timezone: paris})
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	h := NewHistogram(rows)
+	for _, ts := range []time.Time{
+		start, // first instant of the first day
+		time.Date(2025, 3, 29, 23, 59, 59, 0, paris),
+		time.Date(2025, 3, 30, 23, 30, 0, 0, paris),    // 23-hour DST day
+		time.Date(2025, 3, 30, 22, 30, 0, 0, time.UTC), // 31 March 00:30 in Paris
+		time.Date(2025, 4, 1, 0, 0, 0, 0, paris),       // end of the range
+		start.Add(-time.Second),
+	} {
+		h.Add(ts)
+	}
+
+	counts := []int{}
+	for _, bucket := range h.Buckets {
+		counts = append(counts, bucket.Count)
+	}
+	assert.Equal(t, []int{2, 1, 1}, counts)
+	assert.Equal(t, 2, h.Outside)
+	assert.Equal(t, 2, h.Max())
+	assert.Zero(t, NewHistogram(nil).Max())
+}
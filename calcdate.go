@@ -3,10 +3,7 @@ package calcdate
 
 import (
 	"fmt"
-	"log"
-	"os"
 	"regexp"
-	"runtime"
 	"strconv"
 	"strings"
 	"time"
@@ -15,6 +12,8 @@ import (
 const (
 	// SecondsPerHour is the number of seconds in an hour.
 	SecondsPerHour = 3600
+	// SecondsPerMinute is the number of seconds in a minute.
+	SecondsPerMinute = 60
 	// MinutesPerHour is the number of minutes in an hour.
 	MinutesPerHour = 60
 	// MonthsPerYear is the number of months in a year.
@@ -140,13 +139,7 @@ func (d *Date) Format(ofmt string) string {
 
 // GetOffset returns the timezone offset in the format +/-HH:MM.
 func (d *Date) GetOffset() string {
-	_, offset := d.Time().Zone()
-	hours := offset / SecondsPerHour
-	minutes := (offset % SecondsPerHour) / MinutesPerHour
-	if offset < 0 {
-		return fmt.Sprintf("-%02d:%02d", -hours, -minutes)
-	}
-	return fmt.Sprintf("+%02d:%02d", hours, minutes)
+	return FormatOffset(d.Time())
 }
 
 // GetTZAbr returns the timezone abbreviation.
@@ -299,58 +292,6 @@ func GetInterval(d1 *Date, d2 *Date) time.Duration {
 	return -diff
 }
 
-// ListTZ prints all available timezones to stdout.
-func ListTZ() {
-	var zoneDirs = map[string]string{
-		"android":   "/system/usr/share/zoneinfo/",
-		"darwin":    "/usr/share/zoneinfo/",
-		"dragonfly": "/usr/share/zoneinfo/",
-		"freebsd":   "/usr/share/zoneinfo/",
-		"linux":     "/usr/share/zoneinfo/",
-		"netbsd":    "/usr/share/zoneinfo/",
-		"openbsd":   "/usr/share/zoneinfo/",
-		"solaris":   "/usr/share/lib/zoneinfo/",
-	}
-
-	var timeZones []string
-
-	// Reads the Directory corresponding to the OS
-	dirFile, _ := os.ReadDir(zoneDirs[runtime.GOOS])
-	for _, i := range dirFile {
-		// Checks if starts with Capital Letter
-		if i.Name() == (strings.ToUpper(i.Name()[:1]) + i.Name()[1:]) {
-			if i.IsDir() {
-				// Recursive read if directory
-				subFiles, err := os.ReadDir(zoneDirs[runtime.GOOS] + i.Name())
-				if err != nil {
-					log.Fatal(err)
-				}
-
-				for _, s := range subFiles {
-					// Appends the path to timeZones var
-					timeZones = append(timeZones, i.Name()+"/"+s.Name())
-				}
-			}
-			// Appends the path to timeZones var
-			timeZones = append(timeZones, i.Name())
-		}
-	}
-	// Loop over timezones and Check Validity, Delete entry if invalid.
-	// Range function doesnt work with changing length.
-	for i := 0; i < len(timeZones); i++ {
-		_, err := time.LoadLocation(timeZones[i])
-		if err != nil {
-			// newSlice = timeZones[:n]  timeZones[n+1:]
-			timeZones = append(timeZones[:i], timeZones[i+1:]...)
-			continue
-		}
-	}
-	// Now we Can range timeZones for printing
-	for _, i := range timeZones {
-		fmt.Println(i)
-	}
-}
-
 //nolint:unused // Used in tests
 func newDateWithSpecificNowFct(date string, ifmt string, tz string, nowFct func() time.Time) (*Date, error) {
 	d := Date{
@@ -0,0 +1,475 @@
+package calcdate
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// calendarSearchYears bounds the search of nextEvent and prevEvent.
+const calendarSearchYears = 32
+
+// CalendarEvent is a VEVENT read from an iCalendar file. Rule is the
+// recurrence rule of recurring events (nil for single events); its
+// occurrences keep the length of the first one.
+type CalendarEvent struct {
+	ICalEvent
+	Rule *RRule
+}
+
+// Calendar is a set of events read from an iCalendar file, used by the
+// nextEvent and prevEvent operations, the isInEvent predicate and
+// SubtractEvents.
+type Calendar struct {
+	Events []CalendarEvent
+}
+
+// ParseICalendar reads the VEVENTs of an iCalendar document. DTSTART, DTEND
+// (or DURATION), RRULE, EXDATE, RECURRENCE-ID, SUMMARY, UID and STATUS are
+// read; cancelled events are dropped. Floating times and dates are read in
+// loc, times with a TZID in that zone of the time zone database.
+func ParseICalendar(r io.Reader, loc *time.Location) (*Calendar, error) {
+	lines, err := unfoldICalLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cal := &Calendar{}
+	overrides := []icalOverride{}
+	var event *icalEventParser
+	nested := 0
+	for _, line := range lines {
+		name, params, value := splitICalProperty(line)
+		switch {
+		case event == nil:
+			if name == "BEGIN" && strings.EqualFold(value, "VEVENT") {
+				event = &icalEventParser{loc: loc}
+			}
+		case name == "BEGIN":
+			nested++
+		case name == "END" && nested > 0:
+			nested--
+		case name == "END":
+			parsed, err := event.finish()
+			if err != nil {
+				return nil, err
+			}
+			if event.hasRecurrenceID {
+				overrides = append(overrides, event.override)
+			}
+			if !event.cancelled {
+				cal.Events = append(cal.Events, parsed)
+			}
+			event = nil
+		default:
+			if err := event.property(name, params, value); err != nil {
+				return nil, fmt.Errorf("%w: %s: %w", ErrInvalidICalendar, name, err)
+			}
+		}
+	}
+	if event != nil {
+		return nil, fmt.Errorf("%w: unterminated VEVENT", ErrInvalidICalendar)
+	}
+
+	// Instances modified or cancelled by a RECURRENCE-ID event are excluded
+	// from the recurring event they override.
+	for _, override := range overrides {
+		for _, e := range cal.Events {
+			if e.Rule != nil && e.UID == override.uid {
+				e.Rule.ExDates = append(e.Rule.ExDates, override.start)
+				e.Rule.exDays = append(e.Rule.exDays, override.dateOnly)
+			}
+		}
+	}
+	return cal, nil
+}
+
+// unfoldICalLines returns the content lines of an iCalendar document,
+// joining folded lines.
+func unfoldICalLines(r io.Reader) ([]string, error) {
+	lines := []string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidICalendar, err)
+	}
+	return lines, nil
+}
+
+// splitICalProperty splits "DTSTART;TZID=Europe/Paris:20250708T090000" into
+// its upper-cased name, its parameters and its value. Quoted parameter
+// values may contain colons.
+func splitICalProperty(line string) (string, string, string) {
+	quoted := false
+	for i, ch := range line {
+		switch {
+		case ch == '"':
+			quoted = !quoted
+		case ch == ':' && !quoted:
+			name, params, _ := strings.Cut(line[:i], ";")
+			return strings.ToUpper(name), params, line[i+1:]
+		}
+	}
+	return strings.ToUpper(line), "", ""
+}
+
+// icalOverride is the instance of a recurring event replaced by another
+// event through its RECURRENCE-ID.
+type icalOverride struct {
+	uid      string
+	start    time.Time
+	dateOnly bool
+}
+
+// icalEventParser collects the properties of a VEVENT.
+type icalEventParser struct {
+	loc              *time.Location
+	event            CalendarEvent
+	hasStart, hasEnd bool
+	allDay           bool
+	duration         string
+	rrule            string
+	exdates          [][2]string
+	hasRecurrenceID  bool
+	override         icalOverride
+	cancelled        bool
+}
+
+func (p *icalEventParser) property(name, params, value string) error {
+	var err error
+	switch name {
+	case "UID":
+		p.event.UID = value
+		p.override.uid = value
+	case "SUMMARY":
+		p.event.Summary = unescapeICalText(value)
+	case "DESCRIPTION":
+		p.event.Description = unescapeICalText(value)
+	case "DTSTART":
+		p.event.Start, p.allDay, err = p.date(params, value)
+		p.hasStart = true
+	case "DTEND":
+		p.event.End, _, err = p.date(params, value)
+		p.hasEnd = true
+	case "DURATION":
+		p.duration = value
+	case "RRULE":
+		p.rrule = value
+	case "EXDATE":
+		p.exdates = append(p.exdates, [2]string{params, value})
+	case "RECURRENCE-ID":
+		p.override.start, p.override.dateOnly, err = p.date(params, value)
+		p.hasRecurrenceID = true
+	case "STATUS":
+		p.cancelled = strings.EqualFold(value, "CANCELLED")
+	}
+	return err
+}
+
+// date parses a DATE or DATE-TIME value in the zone of its TZID parameter.
+func (p *icalEventParser) date(params, value string) (time.Time, bool, error) {
+	loc, err := paramLocation(params, p.loc)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return ParseICalDate(value, loc)
+}
+
+func (p *icalEventParser) finish() (CalendarEvent, error) {
+	event := p.event
+	if !p.hasStart {
+		return CalendarEvent{}, fmt.Errorf("%w: VEVENT %q without DTSTART", ErrInvalidICalendar, event.UID)
+	}
+	switch {
+	case p.hasEnd:
+	case p.duration != "":
+		days, clock, err := parseICalDuration(p.duration)
+		if err != nil {
+			return CalendarEvent{}, fmt.Errorf("%w: VEVENT %q: %w", ErrInvalidICalendar, event.UID, err)
+		}
+		event.End = event.Start.AddDate(0, 0, days).Add(clock)
+	case p.allDay:
+		event.End = event.Start.AddDate(0, 0, 1)
+	default:
+		event.End = event.Start
+	}
+	if event.End.Before(event.Start) {
+		return CalendarEvent{}, fmt.Errorf("%w: VEVENT %q ends before it starts", ErrInvalidICalendar, event.UID)
+	}
+
+	if p.rrule == "" {
+		return event, nil
+	}
+	rule, err := ParseRRule("RRULE:"+p.rrule, event.Start.Location())
+	if err != nil {
+		return CalendarEvent{}, fmt.Errorf("%w: VEVENT %q: %w", ErrInvalidICalendar, event.UID, err)
+	}
+	for _, exdate := range p.exdates {
+		loc, err := paramLocation(exdate[0], event.Start.Location())
+		if err == nil {
+			err = rule.AddExDates(exdate[1], loc)
+		}
+		if err != nil {
+			return CalendarEvent{}, fmt.Errorf("%w: VEVENT %q: %w", ErrInvalidICalendar, event.UID, err)
+		}
+	}
+	event.Rule = rule
+	return event, nil
+}
+
+// parseICalDuration parses a DURATION such as "PT1H30M", "P2D" or "P1W" into
+// calendar days and a clock duration.
+func parseICalDuration(value string) (int, time.Duration, error) {
+	sign := 1
+	rest := value
+	if strings.HasPrefix(rest, "-") {
+		sign, rest = -1, rest[1:]
+	}
+	rest = strings.TrimPrefix(rest, "+")
+	if !strings.HasPrefix(rest, "P") {
+		return 0, 0, fmt.Errorf("%w: %s", ErrInvalidDuration, value)
+	}
+
+	days := 0
+	var clock time.Duration
+	inTime := false
+	number := ""
+	parts := 0
+	for _, ch := range rest[1:] {
+		switch {
+		case ch >= '0' && ch <= '9':
+			number += string(ch)
+			continue
+		case ch == 'T' && number == "":
+			inTime = true
+			continue
+		}
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w: %s", ErrInvalidDuration, value)
+		}
+		switch {
+		case ch == 'W' && !inTime:
+			days += n * DaysInWeek
+		case ch == 'D' && !inTime:
+			days += n
+		case ch == 'H' && inTime:
+			clock += time.Duration(n) * time.Hour
+		case ch == 'M' && inTime:
+			clock += time.Duration(n) * time.Minute
+		case ch == 'S' && inTime:
+			clock += time.Duration(n) * time.Second
+		default:
+			return 0, 0, fmt.Errorf("%w: %s", ErrInvalidDuration, value)
+		}
+		number = ""
+		parts++
+	}
+	if number != "" || parts == 0 {
+		return 0, 0, fmt.Errorf("%w: %s", ErrInvalidDuration, value)
+	}
+	return sign * days, time.Duration(sign) * clock, nil
+}
+
+// unescapeICalText reverses escapeICalText.
+func unescapeICalText(text string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(text)
+}
+
+// endAt returns the end of the occurrence starting at start: the event's
+// length in calendar days plus its remaining clock duration, so all-day
+// events stay whole days across DST changes.
+func (e CalendarEvent) endAt(start time.Time) time.Time {
+	days := daysSince(e.Start, e.End)
+	rest := e.End.Sub(e.Start.AddDate(0, 0, days))
+	return start.AddDate(0, 0, days).Add(rest)
+}
+
+// Occurrences returns the events overlapping [from, to], recurring events
+// expanded, sorted by start. An event is [Start, End); an event without
+// length overlaps when it starts within [from, to].
+func (c *Calendar) Occurrences(from, to time.Time) ([]ICalEvent, error) {
+	occurrences := []ICalEvent{}
+	for _, event := range c.Events {
+		if event.Rule == nil {
+			if overlapsEvent(event.ICalEvent, from, to) {
+				occurrences = append(occurrences, event.ICalEvent)
+			}
+			continue
+		}
+
+		// Occurrences starting up to one event length (and a day of DST
+		// slack) before from may still overlap it.
+		lookback := event.End.Sub(event.Start) + HoursInDay*time.Hour
+		starts, err := event.Rule.between(event.Start, from.Add(-lookback), to)
+		if err != nil {
+			return nil, err
+		}
+		for _, start := range starts {
+			occurrence := event.ICalEvent
+			occurrence.Start, occurrence.End = start, event.endAt(start)
+			if overlapsEvent(occurrence, from, to) {
+				occurrences = append(occurrences, occurrence)
+			}
+		}
+		if len(occurrences) > MaxIterations {
+			return nil, ErrTooManyIterations
+		}
+	}
+	slices.SortStableFunc(occurrences, func(a, b ICalEvent) int {
+		return a.Start.Compare(b.Start)
+	})
+	return occurrences, nil
+}
+
+func overlapsEvent(event ICalEvent, from, to time.Time) bool {
+	if event.End.Equal(event.Start) {
+		return !event.Start.Before(from) && !event.Start.After(to)
+	}
+	return !event.Start.After(to) && event.End.After(from)
+}
+
+// Next returns the first event starting after t.
+func (c *Calendar) Next(t time.Time) (ICalEvent, error) {
+	for years := 1; ; years *= 2 {
+		years = min(years, calendarSearchYears)
+		occurrences, err := c.Occurrences(t, t.AddDate(years, 0, 0))
+		if err != nil {
+			return ICalEvent{}, err
+		}
+		for _, occurrence := range occurrences {
+			if occurrence.Start.After(t) {
+				return occurrence, nil
+			}
+		}
+		if years == calendarSearchYears {
+			return ICalEvent{}, fmt.Errorf("%w: none starts within %d years after %s",
+				ErrNoEvent, calendarSearchYears, t.Format(time.DateTime))
+		}
+	}
+}
+
+// Prev returns the last event starting before t.
+func (c *Calendar) Prev(t time.Time) (ICalEvent, error) {
+	for years := 1; ; years *= 2 {
+		years = min(years, calendarSearchYears)
+		occurrences, err := c.Occurrences(t.AddDate(-years, 0, 0), t)
+		if err != nil {
+			return ICalEvent{}, err
+		}
+		for _, occurrence := range slices.Backward(occurrences) {
+			if occurrence.Start.Before(t) {
+				return occurrence, nil
+			}
+		}
+		if years == calendarSearchYears {
+			return ICalEvent{}, fmt.Errorf("%w: none starts within %d years before %s",
+				ErrNoEvent, calendarSearchYears, t.Format(time.DateTime))
+		}
+	}
+}
+
+// Contains reports whether t falls within an event.
+func (c *Calendar) Contains(t time.Time) (bool, error) {
+	occurrences, err := c.Occurrences(t, t)
+	return len(occurrences) > 0, err
+}
+
+// SubtractEvents removes the events of cal from iteration rows: a row
+// [BeginTime, EndTime) is cut around the events it overlaps, keeping its
+// index on every remaining piece, and a single date (BeginTime equal to
+// EndTime) is dropped when it falls within an event. Events without length
+// remove nothing.
+func SubtractEvents(rows []IterationResult, cal *Calendar) ([]IterationResult, error) {
+	kept := []IterationResult{}
+	for _, row := range rows {
+		occurrences, err := cal.Occurrences(row.BeginTime, row.EndTime)
+		if err != nil {
+			return nil, err
+		}
+
+		begin := row.BeginTime
+		covered := false
+		for _, occurrence := range occurrences {
+			if !occurrence.End.After(occurrence.Start) {
+				continue
+			}
+			covered = true
+			if occurrence.Start.After(begin) {
+				kept = append(kept, IterationResult{BeginTime: begin, EndTime: occurrence.Start, Index: row.Index})
+			}
+			if occurrence.End.After(begin) {
+				begin = occurrence.End
+			}
+		}
+		if row.EndTime.After(begin) || (!covered && begin.Equal(row.EndTime)) {
+			kept = append(kept, IterationResult{BeginTime: begin, EndTime: row.EndTime, Index: row.Index})
+		}
+	}
+	return kept, nil
+}
+
+// lookupCalendar returns the calendar registered under name, matched
+// case-insensitively.
+func lookupCalendar(calendars map[string]*Calendar, name string) (*Calendar, error) {
+	if cal, ok := calendars[name]; ok {
+		return cal, nil
+	}
+	for key, cal := range calendars {
+		if strings.EqualFold(key, name) {
+			return cal, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownCalendar, name)
+}
+
+// isPlainCalendarName reports whether a calendar name can be written
+// without quotes in an expression.
+func isPlainCalendarName(name string) bool {
+	for _, ch := range name {
+		if !unicode.IsLetter(ch) && !unicode.IsDigit(ch) && ch != '_' {
+			return false
+		}
+	}
+	return name != ""
+}
+
+func isEventOperation(op string) bool {
+	return op == "nextevent" || op == "prevevent"
+}
+
+// applyEventOperation moves the date to the start of the next or previous
+// event of a calendar, in the date's location.
+func applyEventOperation(date time.Time, op, value string, calendars map[string]*Calendar) (operationResult, bool) {
+	if !isEventOperation(op) {
+		return operationResult{}, false
+	}
+	cal, err := lookupCalendar(calendars, value)
+	if err != nil {
+		return operationResult{time.Time{}, err}, true
+	}
+	var event ICalEvent
+	if op == "prevevent" {
+		event, err = cal.Prev(date)
+	} else {
+		event, err = cal.Next(date)
+	}
+	if err != nil {
+		return operationResult{time.Time{}, err}, true
+	}
+	return operationResult{event.Start.In(date.Location()), nil}, true
+}
@@ -0,0 +1,251 @@
+package calcdate
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testICalendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:freeze\r\n" +
+	"SUMMARY:Year-end\\, freeze\r\n" +
+	"DTSTART;VALUE=DATE:20251220\r\n" +
+	"DTEND;VALUE=DATE:20260105\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:release\r\n" +
+	"SUMMARY:Release\r\n" +
+	"DTSTART;TZID=Europe/Paris:20250902T140000\r\n" +
+	"DURATION:PT2H\r\n" +
+	"RRULE:FREQ=WEEKLY;BYDAY=TU\r\n" +
+	"EXDATE;TZID=Europe/Paris:2025\r\n 0916T140000\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"TRIGGER:-PT15M\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:release\r\n" +
+	"RECURRENCE-ID;TZID=Europe/Paris:20250923T140000\r\n" +
+	"DTSTART;TZID=Europe/Paris:20250924T100000\r\n" +
+	"DTEND;TZID=Europe/Paris:20250924T120000\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:cancelled\r\n" +
+	"STATUS:CANCELLED\r\n" +
+	"DTSTART:20250910T080000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func mustParseICalendar(t *testing.T) *Calendar {
+	t.Helper()
+	cal, err := ParseICalendar(strings.NewReader(testICalendar), mustLoad(t, "Europe/Paris"))
+	require.NoError(t, err)
+	return cal
+}
+
+func TestParseICalendar(t *testing.T) {
+	cal := mustParseICalendar(t)
+	require.Len(t, cal.Events, 3)
+
+	freeze := cal.Events[0]
+	assert.Equal(t, "Year-end, freeze", freeze.Summary)
+	assert.Equal(t, "2025-12-20 00:00:00 +0100", freeze.Start.Format("2006-01-02 15:04:05 -0700"))
+	assert.Equal(t, "2026-01-05 00:00:00", freeze.End.Format(time.DateTime))
+	assert.Nil(t, freeze.Rule)
+
+	release := cal.Events[1]
+	require.NotNil(t, release.Rule)
+	assert.Equal(t, "Europe/Paris", release.Start.Location().String())
+	assert.Equal(t, 2*time.Hour, release.End.Sub(release.Start))
+	// the EXDATE and the RECURRENCE-ID of the moved instance
+	assert.Len(t, release.Rule.ExDates, 2)
+}
+
+func TestParseICalendarErrors(t *testing.T) {
+	tests := []string{
+		"BEGIN:VEVENT\nSUMMARY:no start\nEND:VEVENT\n",
+		"BEGIN:VEVENT\nDTSTART:20250101\n",
+		"BEGIN:VEVENT\nDTSTART:2025-13-01\nEND:VEVENT\n",
+		"BEGIN:VEVENT\nDTSTART;TZID=Nowhere/City:20250101T090000\nEND:VEVENT\n",
+		"BEGIN:VEVENT\nDTSTART:20250101\nDURATION:1H\nEND:VEVENT\n",
+		"BEGIN:VEVENT\nDTSTART:20250102\nDTEND:20250101\nEND:VEVENT\n",
+		"BEGIN:VEVENT\nDTSTART:20250101\nRRULE:FREQ=SOMETIMES\nEND:VEVENT\n",
+	}
+	for _, ics := range tests {
+		_, err := ParseICalendar(strings.NewReader(ics), time.UTC)
+		require.ErrorIs(t, err, ErrInvalidICalendar, ics)
+	}
+}
+
+func TestParseICalDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		days  int
+		clock time.Duration
+	}{
+		{"PT1H30M", 0, 90 * time.Minute},
+		{"P2D", 2, 0},
+		{"P1W", 7, 0},
+		{"P1DT12H", 1, 12 * time.Hour},
+		{"-PT15M", 0, -15 * time.Minute},
+	}
+	for _, tc := range tests {
+		days, clock, err := parseICalDuration(tc.value)
+		require.NoError(t, err, tc.value)
+		assert.Equal(t, tc.days, days, tc.value)
+		assert.Equal(t, tc.clock, clock, tc.value)
+	}
+	for _, value := range []string{"", "P", "PT", "1H", "P1H", "PT1D", "P1"} {
+		_, _, err := parseICalDuration(value)
+		assert.ErrorIs(t, err, ErrInvalidDuration, value)
+	}
+}
+
+func TestCalendarOccurrences(t *testing.T) {
+	cal := mustParseICalendar(t)
+	paris := mustLoad(t, "Europe/Paris")
+
+	occurrences, err := cal.Occurrences(
+		time.Date(2025, 9, 1, 0, 0, 0, 0, paris), time.Date(2025, 10, 1, 0, 0, 0, 0, paris))
+	require.NoError(t, err)
+	starts := []string{}
+	for _, o := range occurrences {
+		starts = append(starts, o.Start.In(paris).Format("01-02 15:04"))
+	}
+	// 09-16 is excluded and 09-23 moved to 09-24 10:00
+	assert.Equal(t, []string{"09-02 14:00", "09-09 14:00", "09-24 10:00", "09-30 14:00"}, starts)
+
+	// an occurrence started before the window still overlaps it
+	occurrences, err = cal.Occurrences(
+		time.Date(2025, 9, 30, 15, 0, 0, 0, paris), time.Date(2025, 9, 30, 15, 30, 0, 0, paris))
+	require.NoError(t, err)
+	require.Len(t, occurrences, 1)
+	assert.Equal(t, "2025-09-30 16:00:00", occurrences[0].End.In(paris).Format(time.DateTime))
+}
+
+func TestCalendarNextPrevContains(t *testing.T) {
+	cal := mustParseICalendar(t)
+	paris := mustLoad(t, "Europe/Paris")
+
+	next, err := cal.Next(time.Date(2025, 9, 10, 0, 0, 0, 0, paris))
+	require.NoError(t, err)
+	assert.Equal(t, "2025-09-24 10:00:00", next.Start.In(paris).Format(time.DateTime))
+
+	prev, err := cal.Prev(time.Date(2025, 9, 20, 0, 0, 0, 0, paris))
+	require.NoError(t, err)
+	assert.Equal(t, "2025-09-09 14:00:00", prev.Start.In(paris).Format(time.DateTime))
+
+	only := &Calendar{Events: cal.Events[:1]}
+	_, err = only.Next(time.Date(2026, 1, 1, 0, 0, 0, 0, paris))
+	require.ErrorIs(t, err, ErrNoEvent)
+
+	tests := []struct {
+		at       time.Time
+		expected bool
+	}{
+		{time.Date(2025, 12, 20, 0, 0, 0, 0, paris), true},
+		{time.Date(2026, 1, 4, 23, 59, 0, 0, paris), true},
+		{time.Date(2026, 1, 5, 0, 0, 0, 0, paris), false},
+		{time.Date(2025, 9, 30, 15, 0, 0, 0, paris), true},
+		{time.Date(2025, 9, 16, 15, 0, 0, 0, paris), false},
+	}
+	for _, tc := range tests {
+		in, err := cal.Contains(tc.at)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, in, tc.at.String())
+	}
+}
+
+func TestSubtractEvents(t *testing.T) {
+	cal := mustParseICalendar(t)
+	paris := mustLoad(t, "Europe/Paris")
+	day := func(d int) time.Time { return time.Date(2025, 12, d, 0, 0, 0, 0, paris) }
+
+	rows := []IterationResult{
+		{BeginTime: day(16), EndTime: day(17), Index: 0},
+		{BeginTime: day(19), EndTime: day(20), Index: 1},
+		{BeginTime: day(20), EndTime: day(21), Index: 2},
+		{BeginTime: day(19).Add(12 * time.Hour), EndTime: day(19).Add(12 * time.Hour), Index: 3},
+		{BeginTime: day(22).Add(9 * time.Hour), EndTime: day(22).Add(9 * time.Hour), Index: 4},
+	}
+	kept, err := SubtractEvents(rows, cal)
+	require.NoError(t, err)
+
+	got := []string{}
+	for _, row := range kept {
+		got = append(got, row.BeginTime.Format("01-02 15:04")+" "+row.EndTime.Format("01-02 15:04"))
+	}
+	assert.Equal(t, []string{
+		// the release on Tuesday 16 from 14:00 to 16:00 splits the day
+		"12-16 00:00 12-16 14:00",
+		"12-16 16:00 12-17 00:00",
+		"12-19 00:00 12-20 00:00",
+		"12-19 12:00 12-19 12:00",
+	}, got)
+	assert.Equal(t, 0, kept[1].Index)
+}
+
+func TestEventOperations(t *testing.T) {
+	ctx := &EvalContext{
+		Timezone:  mustLoad(t, "Europe/Paris"),
+		Calendars: map[string]*Calendar{"Release": mustParseICalendar(t)},
+	}
+
+	tests := []struct {
+		expr     string
+		expected string
+	}{
+		{"2025-09-10 | nextEvent release", "2025-09-24 10:00:00"},
+		{"2025-09-10 | prevEvent release | +1d", "2025-09-10 14:00:00"},
+		{`2025-09-10 | nextEvent "Release"`, "2025-09-24 10:00:00"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			node, err := NewExprParser(tc.expr).Parse(tc.expr)
+			require.NoError(t, err)
+			result, err := node.Evaluate(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result.Format(time.DateTime))
+		})
+	}
+
+	node, err := NewExprParser("").Parse("today | nextEvent unknown")
+	require.NoError(t, err)
+	_, err = node.Evaluate(ctx)
+	require.ErrorIs(t, err, ErrUnknownCalendar)
+
+	_, err = NewExprParser("").Parse("today | nextEvent")
+	require.ErrorIs(t, err, ErrUnknownCalendar)
+}
+
+func TestEventOperationString(t *testing.T) {
+	for expr, expected := range map[string]string{
+		"today | nextEvent freeze":    "today | nextEvent freeze",
+		`today | prevEvent "on-call"`: `today | prevEvent "on-call"`,
+	} {
+		node, err := NewExprParser(expr).Parse(expr)
+		require.NoError(t, err)
+		assert.Equal(t, expected, exprString(node))
+	}
+}
+
+func TestIsInEventPredicate(t *testing.T) {
+	paris := mustLoad(t, "Europe/Paris")
+	ctx := &EvalContext{Calendars: map[string]*Calendar{"freeze": mustParseICalendar(t)}}
+
+	p, err := ParsePredicate("isInEvent Freeze and not isWeekend")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"freeze"}, p.Calendars())
+	assert.True(t, p.Match(time.Date(2025, 12, 22, 9, 0, 0, 0, paris), LocalTimeValid, ctx))
+	assert.False(t, p.Match(time.Date(2025, 12, 20, 9, 0, 0, 0, paris), LocalTimeValid, ctx))
+	assert.False(t, p.Match(time.Date(2025, 12, 19, 9, 0, 0, 0, paris), LocalTimeValid, ctx))
+	assert.False(t, p.Match(time.Date(2025, 12, 22, 9, 0, 0, 0, paris), LocalTimeValid, nil))
+
+	_, err = ParsePredicate("isDST or isInEvent")
+	require.ErrorIs(t, err, ErrInvalidPredicate)
+}
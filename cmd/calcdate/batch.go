@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Errors of --batch.
+var (
+	errBatchExpr   = errors.New("-batch reads the expressions from stdin and cannot be combined with -expr")
+	errBatchEcho   = errors.New("-echo requires -output text")
+	errBatchFailed = errors.New("lines failed")
+)
+
+// runBatch evaluates every line of stdin as an expression, with the same
+// now, zone and format; blank lines and lines starting with '#' are skipped. Failing lines are reported on stderr with their
+// number: the first one stops the batch, unless --keep-going.
+func (r *runner) runBatch(stdin io.Reader, stderr io.Writer) error {
+	if r.config.expr != "" {
+		return errBatchExpr
+	}
+	if r.echo != nil && r.config.output != "" && r.config.output != "text" {
+		return errBatchEcho
+	}
+
+	evaluated, failed := 0, 0
+	scanner := bufio.NewScanner(stdin)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		evaluated++
+		if r.echo != nil {
+			r.echo.prefix = line + "\t"
+		}
+		err := r.processExpressionMode(line)
+		if err == nil {
+			continue
+		}
+		if !r.config.keepGoing {
+			if flushErr := r.out.flush(); flushErr != nil {
+				return flushErr
+			}
+			return fmt.Errorf("line %d: %w", n, err)
+		}
+		failed++
+		fmt.Fprintf(stderr, "line %d: %v\n", n, err)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading from stdin: %w", err)
+	}
+
+	if err := r.out.flush(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d %w", failed, evaluated, errBatchFailed)
+	}
+	if r.where != nil && r.matched == 0 && !r.config.explain {
+		return errNoMatch
+	}
+	return nil
+}
+
+// prefixWriter writes prefix at the start of every line.
+type prefixWriter struct {
+	w           io.Writer
+	prefix      string
+	atLineStart bool
+}
+
+func (pw *prefixWriter) Write(p []byte) (int, error) {
+	var b strings.Builder
+	for _, ch := range string(p) {
+		if pw.atLineStart {
+			b.WriteString(pw.prefix)
+		}
+		b.WriteRune(ch)
+		pw.atLineStart = ch == '\n'
+	}
+	if _, err := io.WriteString(pw.w, b.String()); err != nil {
+		return 0, err //nolint:wrapcheck // write errors are reported as is
+	}
+	return len(p), nil
+}
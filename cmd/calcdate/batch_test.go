@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBatchRunner returns a runner in UTC whose now is 2025-03-01 14:30.
+func newBatchRunner(t *testing.T, config cliConfig, stdout *bytes.Buffer) *runner {
+	t.Helper()
+	config.tz = "UTC"
+	config.format = "iso"
+	config.locale = "en"
+	config.batch = true
+	r, err := newRunner(config, stdout)
+	require.NoError(t, err)
+	r.now = time.Date(2025, 3, 1, 14, 30, 0, 0, time.UTC)
+	return r
+}
+
+func TestRunBatch(t *testing.T) {
+	input := "now +1d\n\n# a comment\n  \nnow\ntoday | endOfMonth\nnow\n"
+
+	var out, stderr bytes.Buffer
+	r := newBatchRunner(t, cliConfig{output: "text"}, &out)
+	require.NoError(t, r.runBatch(strings.NewReader(input), &stderr))
+	// In input order, every "now" being the same instant
+	assert.Equal(t, "2025-03-02T14:30:00Z\n"+
+		"2025-03-01T14:30:00Z\n"+
+		"2025-03-31T23:59:59Z\n"+
+		"2025-03-01T14:30:00Z\n", out.String())
+	assert.Empty(t, stderr.String())
+
+	out.Reset()
+	r = newBatchRunner(t, cliConfig{output: "text", echo: true}, &out)
+	require.NoError(t, r.runBatch(strings.NewReader(input), &stderr))
+	assert.Equal(t, "now +1d\t2025-03-02T14:30:00Z\n"+
+		"now\t2025-03-01T14:30:00Z\n"+
+		"today | endOfMonth\t2025-03-31T23:59:59Z\n"+
+		"now\t2025-03-01T14:30:00Z\n", out.String())
+}
+
+func TestRunBatchErrors(t *testing.T) {
+	input := "now\n# a comment\nbogus\nnow +1d\nbogus too\n"
+
+	// Fail fast: the results before the failing line are written
+	var out, stderr bytes.Buffer
+	r := newBatchRunner(t, cliConfig{output: "text"}, &out)
+	err := r.runBatch(strings.NewReader(input), &stderr)
+	require.Error(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "line 3: "), err.Error())
+	assert.Equal(t, "2025-03-01T14:30:00Z\n", out.String())
+	assert.Empty(t, stderr.String())
+
+	// Keep going: every failing line is reported, then the batch fails
+	out.Reset()
+	r = newBatchRunner(t, cliConfig{output: "text", keepGoing: true}, &out)
+	err = r.runBatch(strings.NewReader(input), &stderr)
+	require.ErrorIs(t, err, errBatchFailed)
+	assert.Equal(t, "2 of 4 lines failed", err.Error())
+	assert.Equal(t, "2025-03-01T14:30:00Z\n2025-03-02T14:30:00Z\n", out.String())
+	lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "line 3: "), lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "line 5: "), lines[1])
+
+	// --echo only applies to text output, and --batch replaces --expr
+	r = newBatchRunner(t, cliConfig{output: "json", echo: true}, &out)
+	require.ErrorIs(t, r.runBatch(strings.NewReader(input), &stderr), errBatchEcho)
+	r = newBatchRunner(t, cliConfig{output: "text", expr: "now"}, &out)
+	require.ErrorIs(t, r.runBatch(strings.NewReader(input), &stderr), errBatchExpr)
+}
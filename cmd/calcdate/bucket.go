@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sgaunet/calcdate/v2"
+)
+
+// errBucketUsage is returned when "calcdate bucket" misses its range.
+var errBucketUsage = errors.New("usage: calcdate bucket [--each=1h] [--bar] [-o text|csv|json] 'RANGE' < TIMESTAMPS")
+
+// barWidth is the length of the --bar of the largest bucket.
+const barWidth = 40
+
+// runBucket implements "calcdate bucket": it counts the timestamps read on
+// stdin, one per line, in the --each intervals of a range, empty intervals
+// included.
+//
+//	calcdate bucket --each 1h --bar 'now -24h...now' < events.txt
+//	calcdate bucket --each 1M -o csv '2025-01-01...2026-01-01' < signups.txt
+//	calcdate bucket --each 1d --input-format '%d/%b/%Y:%H:%M:%S %z' 'today -7d...today' < times.txt
+func runBucket(args []string, stdout io.Writer) error {
+	var config cliConfig
+	var bar bool
+	var layouts listFlag
+
+	flags := flag.NewFlagSet("bucket", flag.ContinueOnError)
+	flags.StringVar(&config.tz, "tz", "Local", "Timezone of the buckets and of timestamps without an offset")
+	flags.StringVar(&config.expr, "expr", "", "Range to count in (e.g., 'now -1d...now')")
+	flags.StringVar(&config.expr, "x", "", "Range to count in (short form)")
+	flags.StringVar(&config.each, "each", "1h", "Bucket interval (e.g., '15m', '1h', '1d', '1M')")
+	flags.Var(&layouts, "input-format",
+		"Timestamp layout: iso, sql, ts, compact, Unix or Go format; the first match wins (repeatable; default: any date calcdate reads)")
+	flags.StringVar(&config.format, "format", "", "Output format of the bounds (same values as calcdate -format)")
+	flags.StringVar(&config.format, "f", "", "Output format (short form)")
+	flags.StringVar(&config.output, "output", "text", "Output type: text, csv, json")
+	flags.StringVar(&config.output, "o", "text", "Output type (short form)")
+	flags.BoolVar(&bar, "bar", false, "With -output text, draw a bar chart of the counts")
+	if err := flags.Parse(args); err != nil {
+		return err //nolint:wrapcheck // flag errors are already printed with the usage
+	}
+	if flags.NArg() > 0 {
+		if config.expr != "" {
+			return errBucketUsage
+		}
+		// The range is one argument; flags may follow it
+		config.expr = flags.Arg(0)
+		if err := flags.Parse(flags.Args()[1:]); err != nil {
+			return err //nolint:wrapcheck // flag errors are already printed with the usage
+		}
+		if flags.NArg() > 0 {
+			return errBucketUsage
+		}
+	}
+	if config.expr == "" {
+		return errBucketUsage
+	}
+	config.locale = "en"
+
+	r, err := newRunner(config, stdout)
+	if err != nil {
+		return err
+	}
+	rows, err := r.rangeRows(errBucketUsage)
+	if err != nil {
+		return err
+	}
+	histogram := calcdate.NewHistogram(rows)
+	if err := r.readBucketTimes(os.Stdin, layouts, histogram); err != nil {
+		return err
+	}
+
+	switch config.output {
+	case "csv":
+		return r.writeBucketsCSV(histogram)
+	case "json":
+		return r.writeBucketsJSON(histogram)
+	default:
+		return r.writeBuckets(histogram, bar)
+	}
+}
+
+// readBucketTimes counts the timestamp of every non-empty line.
+func (r *runner) readBucketTimes(stdin io.Reader, layouts []string, histogram *calcdate.Histogram) error {
+	scanner := bufio.NewScanner(stdin)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var t time.Time
+		var err error
+		if len(layouts) > 0 {
+			t, _, err = calcdate.ParseLayouts(line, layouts, r.tz)
+		} else {
+			t, err = calcdate.ParseDateValue(line, r.newContext())
+		}
+		if err != nil {
+			return failure(fmt.Sprintf("Invalid timestamp on line %d", n), err)
+		}
+		histogram.Add(t)
+	}
+	if err := scanner.Err(); err != nil {
+		return failure("Failed to read timestamps", err)
+	}
+	return nil
+}
+
+// writeBuckets prints the start, end and count of each bucket, aligned, and
+// with bar a line of '#' proportional to the count.
+func (r *runner) writeBuckets(histogram *calcdate.Histogram, bar bool) error {
+	const columnPadding = 2
+	tw := tabwriter.NewWriter(r.stdout, 0, 0, columnPadding, ' ', 0)
+	highest := histogram.Max()
+	for _, b := range histogram.Buckets {
+		fmt.Fprintf(tw, "%s\t%s\t%d", r.formatTime(b.BeginTime), r.formatTime(b.EndTime), b.Count)
+		if bar && b.Count > 0 {
+			fmt.Fprintf(tw, "\t%s", strings.Repeat("#", (b.Count*barWidth+highest-1)/highest))
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush() //nolint:wrapcheck // write errors are reported as is
+}
+
+func (r *runner) writeBucketsCSV(histogram *calcdate.Histogram) error {
+	writer := csv.NewWriter(r.stdout)
+	_ = writer.Write([]string{"begin", "end", "count"})
+	for _, b := range histogram.Buckets {
+		_ = writer.Write([]string{r.formatTime(b.BeginTime), r.formatTime(b.EndTime), strconv.Itoa(b.Count)})
+	}
+	writer.Flush()
+	return writer.Error() //nolint:wrapcheck // write errors are reported as is
+}
+
+// bucketRecord is a bucket as printed in JSON.
+type bucketRecord struct {
+	Begin string `json:"begin"`
+	End   string `json:"end"`
+	Count int    `json:"count"`
+}
+
+func (r *runner) writeBucketsJSON(histogram *calcdate.Histogram) error {
+	doc := struct {
+		Buckets []bucketRecord `json:"buckets"`
+		Outside int            `json:"outside"`
+	}{Buckets: []bucketRecord{}, Outside: histogram.Outside}
+	for _, b := range histogram.Buckets {
+		doc.Buckets = append(doc.Buckets, bucketRecord{r.formatTime(b.BeginTime), r.formatTime(b.EndTime), b.Count})
+	}
+	encoder := json.NewEncoder(r.stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc) //nolint:wrapcheck // write errors are reported as is
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withStdin replaces os.Stdin with a file holding input for the test.
+func withStdin(t *testing.T, input string) {
+	t.Helper()
+	name := filepath.Join(t.TempDir(), "stdin")
+	require.NoError(t, os.WriteFile(name, []byte(input), 0o600))
+	f, err := os.Open(name)
+	require.NoError(t, err)
+	stdin := os.Stdin
+	os.Stdin = f
+	t.Cleanup(func() {
+		os.Stdin = stdin
+		f.Close()
+	})
+}
+
+func TestRunBucketFlagsAfterRange(t *testing.T) {
+	withStdin(t, "2025-01-01 00:30:00\n2025-01-01 01:10:00\n2025-01-01 01:20:00\n")
+
+	var out bytes.Buffer
+	require.NoError(t, runBucket([]string{"--tz", "UTC", "2025-01-01...2025-01-01 03:00:00", "--each", "1h", "-o", "csv"}, &out))
+	assert.Equal(t, "begin,end,count\n"+
+		"2025-01-01 00:00:00,2025-01-01 01:00:00,1\n"+
+		"2025-01-01 01:00:00,2025-01-01 02:00:00,2\n"+
+		"2025-01-01 02:00:00,2025-01-01 03:00:00,0\n", out.String())
+
+	require.ErrorIs(t, runBucket([]string{"2025-01-01", "...", "2025-01-02"}, &out), errBucketUsage)
+	require.ErrorIs(t, runBucket([]string{}, &out), errBucketUsage)
+	require.ErrorIs(t, runBucket([]string{"-h"}, &out), flag.ErrHelp)
+}
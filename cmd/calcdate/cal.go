@@ -0,0 +1,285 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sgaunet/calcdate/v2"
+)
+
+// Errors of "calcdate cal".
+var (
+	errCalUsage = errors.New(
+		"usage: calcdate cal [--year | --months=N] [--highlight=EXPR [--each=1d] [--where=PREDICATE]] [DATE]")
+	errCalColor = errors.New("-color must be auto, always or never")
+)
+
+// Layout of the month grids.
+const (
+	calMonthsPerRow = 3
+	calMonthsInYear = 12
+	calMonthGap     = "   "
+	// calCellWidth is a separating space, a day number and the column of
+	// its highlight marker, so a marker never touches the next day.
+	calCellWidth = 4
+	// calWeekWidth is the ISO week number column.
+	calWeekWidth = 2
+)
+
+// runCal implements "calcdate cal": it prints the grid of the month holding
+// a date expression (today by default), like cal, with ISO week numbers and
+// the week starting on --week-start. The days produced by --highlight, a
+// date or an iteration of a range filtered by --where and --skip-weekends,
+// are highlighted: in reverse video on terminals, followed by '*' otherwise.
+// Without --highlight, the date itself is.
+//
+//	calcdate cal --year
+//	calcdate cal --highlight '2025-03-01...2025-03-31' --where isWorkday 2025-03-01
+//	calcdate cal --months 3 --calendar holidays=fr.ics --highlight 'today...+3M' --where 'isInEvent holidays'
+func runCal(args []string, stdout io.Writer) error {
+	var config cliConfig
+	var year bool
+	var months int
+	var highlight, color string
+
+	flags := flag.NewFlagSet("cal", flag.ContinueOnError)
+	flags.StringVar(&config.tz, "tz", "Local", "Timezone of the dates")
+	flags.BoolVar(&year, "year", false, "Print the whole year")
+	flags.IntVar(&months, "months", 1, "Number of months to print, from the month of the date")
+	flags.StringVar(&highlight, "highlight", "",
+		"Highlight a date, or the iteration of a range (e.g., 'today...+2w')")
+	flags.StringVar(&config.each, "each", "1d", "Iteration interval of a -highlight range")
+	flags.BoolVar(&config.skipWeekends, "skip-weekends", false, "Do not highlight weekend days (see -weekend)")
+	flags.StringVar(&config.where, "where", "",
+		"Highlight only the dates matching a predicate (e.g., 'isWorkday', 'isInEvent holidays')")
+	flags.Var(&config.calendars, "calendar", "Named iCalendar file for isInEvent (e.g., 'holidays=fr.ics'; repeatable)")
+	flags.StringVar(&config.weekStart, "week-start", "monday", "First day of the week")
+	flags.StringVar(&config.weekend, "weekend", "", "Comma-separated weekend days (default \"sat,sun\")")
+	flags.StringVar(&config.locale, "locale", "en", "Locale of month and weekday names: en, fr, de, es")
+	flags.StringVar(&color, "color", "auto", "Highlight in reverse video: auto (on terminals), always, never")
+	if err := flags.Parse(args); err != nil {
+		return err //nolint:wrapcheck // flag errors are already printed with the usage
+	}
+	expr := "today"
+	if flags.NArg() > 0 {
+		// The date is one argument; flags may follow it
+		expr = flags.Arg(0)
+		if err := flags.Parse(flags.Args()[1:]); err != nil {
+			return err //nolint:wrapcheck // flag errors are already printed with the usage
+		}
+	}
+	if months < 1 || flags.NArg() > 0 {
+		return errCalUsage
+	}
+	reverse, err := useReverseVideo(color, stdout)
+	if err != nil {
+		return err
+	}
+
+	r, err := newRunner(config, stdout)
+	if err != nil {
+		return err
+	}
+	date, err := r.evaluateDate(expr)
+	if err != nil {
+		return err
+	}
+	date = date.In(r.tz)
+
+	marked := map[string]bool{date.Format(time.DateOnly): true}
+	if highlight != "" {
+		if marked, err = r.highlightedDays(highlight); err != nil {
+			return err
+		}
+	}
+
+	first := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, r.tz)
+	if year {
+		first, months = time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, r.tz), calMonthsInYear
+	}
+	grid := calGrid{r: r, marked: marked, reverse: reverse, withYear: !year}
+	var b strings.Builder
+	if year {
+		width := calMonthsPerRow*grid.width() + (calMonthsPerRow-1)*len(calMonthGap)
+		b.WriteString(strings.TrimRight(center(fmt.Sprint(date.Year()), width), " ") + "\n\n")
+	}
+	for i := 0; i < months; i += calMonthsPerRow {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		row := []time.Time{}
+		for j := i; j < min(i+calMonthsPerRow, months); j++ {
+			row = append(row, first.AddDate(0, j, 0))
+		}
+		grid.writeMonths(&b, row)
+	}
+	_, err = io.WriteString(r.stdout, b.String())
+	return err //nolint:wrapcheck // write errors are reported as is
+}
+
+// useReverseVideo tells whether highlighted days are printed in reverse video.
+func useReverseVideo(color string, stdout io.Writer) (bool, error) {
+	switch color {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto":
+		f, ok := stdout.(*os.File)
+		if !ok {
+			return false, nil
+		}
+		stat, err := f.Stat()
+		return err == nil && stat.Mode()&os.ModeCharDevice != 0, nil
+	default:
+		return false, errCalColor
+	}
+}
+
+// highlightedDays returns the days, as "2006-01-02" in --tz, of a date or of
+// the iteration of a range, filtered like calcdate iterations.
+func (r *runner) highlightedDays(expr string) (map[string]bool, error) {
+	node, err := calcdate.NewExprParser("").Parse(expr)
+	if err != nil {
+		return nil, failure("Failed to parse expression", err)
+	}
+	days := map[string]bool{}
+	isRange := false
+	switch n := node.(type) {
+	case *calcdate.RangeNode:
+		isRange = true
+	case *calcdate.PipeNode:
+		_, isRange = n.Base.(*calcdate.RangeNode)
+	}
+	if !isRange {
+		ctx := r.newContext()
+		t, status, err := calcdate.EvaluateLocalTime(node, ctx)
+		if err != nil {
+			return nil, failure("Failed to evaluate expression", err)
+		}
+		if r.keep(t, status, ctx) {
+			days[t.In(r.tz).Format(time.DateOnly)] = true
+		}
+		return days, nil
+	}
+
+	r.config.expr = expr
+	rows, err := r.rangeRows(errCalUsage)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if r.config.skipWeekends && r.week.IsWeekend(row.BeginTime) {
+			continue
+		}
+		if r.keepRange(row.BeginTime) {
+			days[row.BeginTime.Format(time.DateOnly)] = true
+		}
+	}
+	return days, nil
+}
+
+// calGrid renders months side by side.
+type calGrid struct {
+	r        *runner
+	marked   map[string]bool
+	reverse  bool
+	withYear bool
+}
+
+// width is the width of a month: the week number and the seven days.
+func (g calGrid) width() int {
+	return calWeekWidth + calcdate.DaysInWeek*calCellWidth
+}
+
+// writeMonths writes the months of one row of grids: their titles, the
+// weekday names and the weeks, padded to the longest month.
+func (g calGrid) writeMonths(b *strings.Builder, months []time.Time) {
+	lines := make([][]string, len(months))
+	height := 0
+	for i, month := range months {
+		lines[i] = g.month(month)
+		height = max(height, len(lines[i]))
+	}
+	for l := range height {
+		var line strings.Builder
+		for i := range months {
+			if i > 0 {
+				line.WriteString(calMonthGap)
+			}
+			cell := strings.Repeat(" ", g.width())
+			if l < len(lines[i]) {
+				cell = lines[i][l]
+			}
+			line.WriteString(cell)
+		}
+		b.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+	}
+}
+
+// month returns the lines of a month grid, each g.width() columns wide
+// (escape sequences aside).
+func (g calGrid) month(month time.Time) []string {
+	locale := g.r.locale
+	title := locale.Months[month.Month()-1]
+	if g.withYear {
+		title += " " + fmt.Sprint(month.Year())
+	}
+	lines := []string{center(title, g.width())}
+
+	header := pad("Wk", calWeekWidth)
+	for i := range calcdate.DaysInWeek {
+		day := locale.ShortWeekdays[(int(g.r.week.Start)+i)%calcdate.DaysInWeek]
+		header += pad(" "+truncate(day, calCellWidth-2), calCellWidth)
+	}
+	lines = append(lines, header)
+
+	for _, row := range calcdate.MonthGrid(month, g.r.week) {
+		line := fmt.Sprintf("%2d", calcdate.GridWeekNumber(row))
+		for _, day := range row {
+			line += g.day(day, month.Month())
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// day renders a cell: blank outside the month, the day number followed by
+// '*' when highlighted, or in reverse video.
+func (g calGrid) day(day time.Time, month time.Month) string {
+	if day.Month() != month {
+		return strings.Repeat(" ", calCellWidth)
+	}
+	number := fmt.Sprintf("%2d", day.Day())
+	switch {
+	case !g.marked[day.Format(time.DateOnly)]:
+		return " " + number + " "
+	case g.reverse:
+		return " \x1b[7m" + number + "\x1b[0m "
+	default:
+		return " " + number + "*"
+	}
+}
+
+// center centers s in width columns.
+func center(s string, width int) string {
+	left := max(0, (width-utf8.RuneCountInString(s))/2)
+	return pad(strings.Repeat(" ", left)+s, width)
+}
+
+// pad left-aligns s in width columns.
+func pad(s string, width int) string {
+	return s + strings.Repeat(" ", max(0, width-utf8.RuneCountInString(s)))
+}
+
+// truncate keeps the first n runes of s.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	return string(runes[:min(n, len(runes))])
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCalMarkerColumn(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, runCal([]string{"--tz", "UTC", "2025-03-01",
+		"--highlight", "2025-03-14...2025-03-17", "--color", "never"}, &out))
+	assert.Equal(t, "          March 2025\n"+
+		"Wk Mo  Tu  We  Th  Fr  Sa  Su\n"+
+		" 9                      1   2\n"+
+		"10  3   4   5   6   7   8   9\n"+
+		"11 10  11  12  13  14* 15* 16*\n"+
+		"12 17  18  19  20  21  22  23\n"+
+		"13 24  25  26  27  28  29  30\n"+
+		"14 31\n", out.String())
+
+	require.ErrorIs(t, runCal([]string{"2025-03-01", "2025-04-01"}, &out), errCalUsage)
+	require.ErrorIs(t, runCal([]string{"-h"}, &out), flag.ErrHelp)
+}
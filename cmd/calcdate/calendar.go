@@ -0,0 +1,100 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sgaunet/calcdate/v2"
+)
+
+// Errors of the --calendar flag.
+var (
+	errCalendarFlag      = errors.New("expected name=file, e.g. 'freeze=freeze.ics'")
+	errDuplicateCalendar = errors.New("calendar given twice")
+)
+
+// listFlag is a flag that can be given several times.
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+// Set appends a value.
+func (l *listFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// loadCalendars reads the --calendar files, keyed by lower-cased name, and
+// checks the calendars named by --subtract and --where exist.
+func (r *runner) loadCalendars() error {
+	r.calendars = map[string]*calcdate.Calendar{}
+	for _, arg := range r.config.calendars {
+		name, path, ok := strings.Cut(arg, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !ok || name == "" || path == "" {
+			return fmt.Errorf("%w: %s", errCalendarFlag, arg)
+		}
+		if _, ok := r.calendars[name]; ok {
+			return fmt.Errorf("%w: %s", errDuplicateCalendar, name)
+		}
+		cal, err := readCalendar(path, r.tz)
+		if err != nil {
+			return err
+		}
+		r.calendars[name] = cal
+	}
+
+	names := r.subtractNames()
+	if r.where != nil {
+		names = append(names, r.where.Calendars()...)
+	}
+	for _, name := range names {
+		if _, ok := r.calendars[name]; !ok {
+			return fmt.Errorf("%w: %s (give it with -calendar %s=FILE)", calcdate.ErrUnknownCalendar, name, name)
+		}
+	}
+	return nil
+}
+
+// readCalendar reads an iCalendar file; floating times are read in loc.
+func readCalendar(path string, loc *time.Location) (*calcdate.Calendar, error) {
+	f, err := os.Open(path) //nolint:gosec // reading the user's calendar file is the point
+	if err != nil {
+		return nil, err //nolint:wrapcheck // the path error is descriptive
+	}
+	defer f.Close()
+	cal, err := calcdate.ParseICalendar(f, loc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return cal, nil
+}
+
+// subtractNames returns the lower-cased calendar names of --subtract.
+func (r *runner) subtractNames() []string {
+	names := []string{}
+	for _, name := range strings.Split(r.config.subtract, ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// subtractEvents removes the events of the --subtract calendars from
+// iteration rows.
+func (r *runner) subtractEvents(results []calcdate.IterationResult) ([]calcdate.IterationResult, error) {
+	for _, name := range r.subtractNames() {
+		var err error
+		results, err = calcdate.SubtractEvents(results, r.calendars[name])
+		if err != nil {
+			return nil, failure("Failed to subtract calendar "+name, err)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/sgaunet/calcdate/v2"
+)
+
+// errCronUsage is returned for an unknown "calcdate cron" command.
+var errCronUsage = errors.New("usage: calcdate cron explain [--tz=ZONE] [-n=COUNT] 'SCHEDULE'")
+
+// runCron implements "calcdate cron": "explain" describes a schedule field
+// by field and lists its next fire times.
+//
+//	calcdate cron explain "0 9 * * 1-5"
+//	calcdate cron explain --tz=America/New_York -n 10 "@hourly"
+func runCron(args []string, stdout io.Writer) error {
+	if len(args) == 0 || args[0] != "explain" {
+		return errCronUsage
+	}
+
+	var config cliConfig
+	var count int
+
+	flags := flag.NewFlagSet("cron explain", flag.ContinueOnError)
+	flags.StringVar(&config.tz, "tz", "Local", "Timezone of the schedule")
+	flags.IntVar(&count, "n", 5, "Number of next fire times to list")
+	flags.StringVar(&config.format, "format", "", "Output format of the fire times (same values as calcdate -format)")
+	flags.StringVar(&config.format, "f", "", "Output format (short form)")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err //nolint:wrapcheck // flag errors are already printed with the usage
+	}
+	if flags.NArg() == 0 {
+		return errCronUsage
+	}
+	// The schedule is one argument; flags may follow it
+	config.cron = flags.Arg(0)
+	if err := flags.Parse(flags.Args()[1:]); err != nil {
+		return err //nolint:wrapcheck // flag errors are already printed with the usage
+	}
+	if flags.NArg() > 0 {
+		return errCronUsage
+	}
+	config.locale = "en"
+
+	r, err := newRunner(config, stdout)
+	if err != nil {
+		return err
+	}
+	schedule, err := calcdate.ParseCron(config.cron)
+	if err != nil {
+		return failure("Invalid cron schedule", err)
+	}
+	return r.explainCron(schedule, count)
+}
+
+func (r *runner) explainCron(schedule *calcdate.CronSchedule, count int) error {
+	const columnPadding = 2
+	tw := tabwriter.NewWriter(r.stdout, 0, 0, columnPadding, ' ', 0)
+	fmt.Fprintf(tw, "schedule:\t%s\n", schedule.Spec)
+	descriptions := schedule.Describe()
+	for i, name := range calcdate.CronFieldNames {
+		fmt.Fprintf(tw, "%s:\t%s\t(%s)\n", name, descriptions[i], schedule.Fields[i])
+	}
+	if schedule.DaysEither() {
+		fmt.Fprintf(tw, "days:\tday of month or day of week\n")
+	}
+	if err := tw.Flush(); err != nil {
+		return err //nolint:wrapcheck // write errors are reported as is
+	}
+
+	fmt.Fprintf(r.stdout, "next fire times (%s):\n", r.tz)
+	fire := r.newContext().Now.In(r.tz)
+	for range count {
+		next, err := schedule.Next(fire)
+		if err != nil {
+			return failure("Failed to compute fire times", err)
+		}
+		fire = next
+		if _, err := fmt.Fprintf(r.stdout, "  %s\n", r.formatTime(fire)); err != nil {
+			return err //nolint:wrapcheck // write errors are reported as is
+		}
+	}
+	return nil
+}
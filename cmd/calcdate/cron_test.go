@@ -0,0 +1,19 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCronExplainFlagsAfterSchedule(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, runCron([]string{"explain", "0 9 * * 1-5", "--tz", "Europe/Paris", "-n", "1"}, &out))
+	assert.Contains(t, out.String(), "day of week:   Mon-Fri      (1-5)\n")
+	assert.Contains(t, out.String(), "next fire times (Europe/Paris):\n")
+
+	require.ErrorIs(t, runCron([]string{"explain", "0", "9", "*", "*", "1-5"}, &out), errCronUsage)
+	require.ErrorIs(t, runCron([]string{"explain", "--tz", "UTC"}, &out), errCronUsage)
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sgaunet/calcdate/v2"
+)
+
+var (
+	// errDSTRange is returned when the dst expression is not a range.
+	errDSTRange = errors.New("expected a range expression such as '2025-01-01...2026-01-01'")
+	errDSTUsage = errors.New("usage: calcdate dst [--tz=ZONE] [--year=YEAR | 'RANGE'] [-o text|json|csv]")
+)
+
+// runDST implements "calcdate dst": it lists the DST transitions of a zone
+// during a year or a range expression.
+//
+//	calcdate dst --tz=Europe/Paris --year=2025
+//	calcdate dst --tz=America/New_York "today...+1Y"
+func runDST(args []string, stdout io.Writer) error {
+	var config cliConfig
+	var year int
+
+	flags := flag.NewFlagSet("dst", flag.ContinueOnError)
+	flags.StringVar(&config.tz, "tz", "Local", "Timezone to inspect")
+	flags.IntVar(&year, "year", 0, "Year to inspect (default: the current year)")
+	flags.StringVar(&config.expr, "expr", "", "Range expression to inspect instead of a year (e.g., 'today...+1Y')")
+	flags.StringVar(&config.expr, "x", "", "Range expression (short form)")
+	flags.StringVar(&config.format, "format", "", "Output format of the transition instants (same values as calcdate -format)")
+	flags.StringVar(&config.format, "f", "", "Output format (short form)")
+	flags.StringVar(&config.output, "output", "text", "Output type: text, json, csv")
+	flags.StringVar(&config.output, "o", "text", "Output type (short form)")
+	if err := flags.Parse(args); err != nil {
+		return err //nolint:wrapcheck // flag errors are already printed with the usage
+	}
+	if flags.NArg() > 0 {
+		if config.expr != "" {
+			return errDSTUsage
+		}
+		// The range is one argument; flags may follow it
+		config.expr = flags.Arg(0)
+		if err := flags.Parse(flags.Args()[1:]); err != nil {
+			return err //nolint:wrapcheck // flag errors are already printed with the usage
+		}
+		if flags.NArg() > 0 {
+			return errDSTUsage
+		}
+	}
+	config.locale = "en"
+
+	r, err := newRunner(config, stdout)
+	if err != nil {
+		return err
+	}
+
+	start, end, err := r.dstWindow(year)
+	if err != nil {
+		return err
+	}
+
+	transitions, err := calcdate.DSTTransitions(r.tz, start, end)
+	if err != nil {
+		return failure("Failed to list transitions", err)
+	}
+	return r.writeTransitions(transitions)
+}
+
+// dstWindow returns the range to inspect: the range expression, or the year
+// in the zone.
+func (r *runner) dstWindow(year int) (time.Time, time.Time, error) {
+	if r.config.expr == "" {
+		if year == 0 {
+			year = time.Now().In(r.tz).Year()
+		}
+		start := time.Date(year, time.January, 1, 0, 0, 0, 0, r.tz)
+		return start, start.AddDate(1, 0, 0), nil
+	}
+
+	node, err := calcdate.NewExprParser("").Parse(r.config.expr)
+	if err != nil {
+		return time.Time{}, time.Time{}, failure("Failed to parse expression", err)
+	}
+	rangeNode, ok := node.(*calcdate.RangeNode)
+	if !ok {
+		return time.Time{}, time.Time{}, failure("Failed to parse expression", errDSTRange)
+	}
+	return r.evaluateRange(rangeNode)
+}
+
+// transitionRecord is a DST transition as printed in JSON and CSV.
+type transitionRecord struct {
+	At                 string `json:"at"`
+	UTC                string `json:"utc"`
+	OffsetBefore       string `json:"offsetBefore"`
+	OffsetAfter        string `json:"offsetAfter"`
+	AbbreviationBefore string `json:"abbreviationBefore"`
+	AbbreviationAfter  string `json:"abbreviationAfter"`
+	Kind               string `json:"kind"`
+	WindowStart        string `json:"windowStart,omitempty"`
+	WindowEnd          string `json:"windowEnd,omitempty"`
+}
+
+func (r *runner) transitionRecord(t calcdate.DSTTransition) transitionRecord {
+	record := transitionRecord{
+		At:                 r.formatTime(t.At),
+		UTC:                t.At.UTC().Format(time.RFC3339),
+		OffsetBefore:       t.OffsetBefore,
+		OffsetAfter:        t.OffsetAfter,
+		AbbreviationBefore: t.AbbrevBefore,
+		AbbreviationAfter:  t.AbbrevAfter,
+		Kind:               string(t.Kind),
+	}
+	if t.Kind != calcdate.TransitionRename {
+		record.WindowStart = t.WindowStart.Format(time.TimeOnly)
+		record.WindowEnd = t.WindowEnd.Format(time.TimeOnly)
+	}
+	return record
+}
+
+// effect describes what happens to local times, e.g. "02:00:00-03:00:00 skipped".
+func (rec transitionRecord) effect() string {
+	switch calcdate.TransitionKind(rec.Kind) {
+	case calcdate.TransitionGap:
+		return fmt.Sprintf("%s-%s skipped", rec.WindowStart, rec.WindowEnd)
+	case calcdate.TransitionOverlap:
+		return fmt.Sprintf("%s-%s repeated", rec.WindowStart, rec.WindowEnd)
+	default:
+		return "abbreviation only"
+	}
+}
+
+func (r *runner) writeTransitions(transitions []calcdate.DSTTransition) error {
+	records := make([]transitionRecord, len(transitions))
+	for i, t := range transitions {
+		records[i] = r.transitionRecord(t)
+	}
+
+	switch r.config.output {
+	case "", "text":
+		const columnPadding = 3
+		tw := tabwriter.NewWriter(r.stdout, 0, 0, columnPadding, ' ', 0)
+		for _, rec := range records {
+			fmt.Fprintf(tw, "%s\t%s %s -> %s %s\t%s\n", rec.At,
+				rec.OffsetBefore, rec.AbbreviationBefore, rec.OffsetAfter, rec.AbbreviationAfter, rec.effect())
+		}
+		return tw.Flush() //nolint:wrapcheck // write errors are reported as is
+	case "json":
+		encoder := json.NewEncoder(r.stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(records) //nolint:wrapcheck // write errors are reported as is
+	case "csv":
+		cw := csv.NewWriter(r.stdout)
+		_ = cw.Write([]string{"at", "utc", "offset_before", "offset_after",
+			"abbreviation_before", "abbreviation_after", "kind", "window_start", "window_end"})
+		for _, rec := range records {
+			_ = cw.Write([]string{rec.At, rec.UTC, rec.OffsetBefore, rec.OffsetAfter,
+				rec.AbbreviationBefore, rec.AbbreviationAfter, rec.Kind, rec.WindowStart, rec.WindowEnd})
+		}
+		cw.Flush()
+		return cw.Error() //nolint:wrapcheck // write errors are reported as is
+	default:
+		return failure("Invalid output", fmt.Errorf("%w: %s", errUnknownOutput, r.config.output))
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunDSTFlagsAfterRange(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, runDST([]string{"--tz=Europe/Paris", "2025-01-01...2025-06-01", "-o", "csv"}, &out))
+	assert.Equal(t, "at,utc,offset_before,offset_after,abbreviation_before,abbreviation_after,kind,window_start,window_end\n"+
+		"2025-03-30 03:00:00,2025-03-30T01:00:00Z,+01:00,+02:00,CET,CEST,gap,02:00:00,03:00:00\n", out.String())
+
+	require.ErrorIs(t, runDST([]string{"--tz=UTC", "2025-01-01", "...", "2025-06-01"}, &out), errDSTUsage)
+	require.ErrorIs(t, runDST([]string{"-x", "2025-01-01...2025-06-01", "2026-01-01...2026-06-01"}, &out), errDSTUsage)
+	require.ErrorIs(t, runDST([]string{"-h"}, &out), flag.ErrHelp)
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sgaunet/calcdate/v2"
+)
+
+// errGrepUsage is returned when "calcdate grep" misses its range.
+var errGrepUsage = errors.New("usage: calcdate grep [--tz=TZ] [--sorted] 'RANGE' [FILE]...")
+
+// runGrep implements "calcdate grep": it prints the log lines whose leading
+// timestamp is in a range, from start included to end excluded. Lines
+// without a timestamp, such as stack traces, follow the last line with one.
+// With --sorted, files are searched by binary seek and read up to the end of
+// the range only. Like grep, it exits with status 1 when no line matches.
+//
+//	calcdate grep 'now -2h...now' app.log
+//	calcdate grep --sorted --tz UTC '2025-03-01 10:00...2025-03-01 10:15' access.log
+//	journalctl -o short | calcdate grep 'today...now'
+func runGrep(args []string, stdout io.Writer) error {
+	var config cliConfig
+	var sorted bool
+
+	flags := flag.NewFlagSet("grep", flag.ContinueOnError)
+	flags.StringVar(&config.tz, "tz", "Local", "Timezone of the range and of timestamps without an offset")
+	flags.BoolVar(&sorted, "sorted", false,
+		"The files are sorted by time: binary seek to the range start and stop reading at its end")
+	if err := flags.Parse(args); err != nil {
+		return err //nolint:wrapcheck // flag errors are already printed with the usage
+	}
+	if flags.NArg() == 0 {
+		return errGrepUsage
+	}
+	config.locale = "en"
+
+	r, err := newRunner(config, stdout)
+	if err != nil {
+		return err
+	}
+	expr := flags.Arg(0)
+	node, err := calcdate.NewExprParser(expr).Parse(expr)
+	if err != nil {
+		return failure("Failed to parse expression", err)
+	}
+	start, end, err := calcdate.EvaluateRange(node, r.newContext())
+	if err != nil {
+		return failure("Failed to evaluate range", err)
+	}
+
+	g := &logGrep{r: r, start: start, end: end, sorted: sorted, out: bufio.NewWriter(stdout)}
+	files := flags.Args()[1:]
+	if len(files) == 0 {
+		err = g.grepFile(os.Stdin, "")
+	}
+	for _, path := range files {
+		if err = g.grepPath(path, len(files) > 1); err != nil {
+			break
+		}
+	}
+	if flushErr := g.out.Flush(); err == nil {
+		err = flushErr
+	}
+	if err != nil {
+		return err
+	}
+	if g.matched == 0 {
+		return errNoMatch
+	}
+	return nil
+}
+
+// logGrep prints the lines of logs in [start, end).
+type logGrep struct {
+	r          *runner
+	start, end time.Time
+	sorted     bool
+	out        *bufio.Writer
+	matched    int
+}
+
+// grepPath greps a file, prefixing the lines with its path when several
+// files are searched.
+func (g *logGrep) grepPath(path string, prefixed bool) error {
+	f, err := os.Open(path) //nolint:gosec // reading the logs given on the command line is the point
+	if err != nil {
+		return failure("Failed to open log", err)
+	}
+	defer f.Close()
+
+	prefix := ""
+	if prefixed {
+		prefix = path + ":"
+	}
+	return g.grepFile(f, prefix)
+}
+
+// grepFile reads f from the range start when it is a sorted regular file,
+// from its beginning otherwise.
+func (g *logGrep) grepFile(f *os.File, prefix string) error {
+	var log io.Reader = f
+	if info, err := f.Stat(); g.sorted && err == nil && info.Mode().IsRegular() {
+		offset, err := calcdate.SeekLogTime(f, info.Size(), g.start, g.r.tz, g.r.now)
+		if err != nil {
+			return failure("Failed to read log", err)
+		}
+		log = io.NewSectionReader(f, offset, info.Size()-offset)
+	}
+	return g.scan(log, prefix)
+}
+
+func (g *logGrep) scan(log io.Reader, prefix string) error {
+	reader := bufio.NewReader(log)
+	inRange := false
+	for {
+		line, readErr := reader.ReadString('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return failure("Failed to read log", readErr)
+		}
+		if t, ok := calcdate.ParseLogTimestamp(line, g.r.tz, g.r.now); ok {
+			if g.sorted && !t.Before(g.end) {
+				return nil
+			}
+			inRange = !t.Before(g.start) && t.Before(g.end)
+		}
+		if inRange && line != "" {
+			g.matched++
+			g.out.WriteString(prefix)
+			g.out.WriteString(line)
+			if !strings.HasSuffix(line, "\n") {
+				g.out.WriteByte('\n')
+			}
+		}
+		if readErr != nil {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sgaunet/calcdate/v2"
+)
+
+// listTimezones prints the timezones matching the --tz-search, --tz-region
+// and --tz-offset filters, described at the current instant.
+func listTimezones(config cliConfig, w io.Writer) error {
+	zones, err := calcdate.ListTZ(time.Now())
+	if err != nil {
+		return failure("Failed to list timezones", err)
+	}
+
+	filter := calcdate.ZoneFilter{
+		Search: config.tzSearch,
+		Region: config.tzRegion,
+		Offset: config.tzOffset,
+	}
+	zones, err = filter.Filter(zones)
+	if err != nil {
+		return failure("Invalid timezone filter", err)
+	}
+
+	switch config.output {
+	case "", "text":
+		return writeZonesText(w, zones)
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(zones) //nolint:wrapcheck // write errors are reported as is
+	case "csv":
+		return writeZonesCSV(w, zones)
+	default:
+		return failure("Invalid output", fmt.Errorf("%w: %s", errUnknownOutput, config.output))
+	}
+}
+
+// writeZonesText prints one aligned line per zone: name, offset,
+// abbreviation and "DST" when daylight saving time is in effect.
+func writeZonesText(w io.Writer, zones []calcdate.ZoneInfo) error {
+	const columnPadding = 2
+	tw := tabwriter.NewWriter(w, 0, 0, columnPadding, ' ', 0)
+	for _, z := range zones {
+		dst := ""
+		if z.DST {
+			dst = "DST"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", z.Name, z.Offset, z.Abbreviation, dst)
+	}
+	return tw.Flush() //nolint:wrapcheck // write errors are reported as is
+}
+
+func writeZonesCSV(w io.Writer, zones []calcdate.ZoneInfo) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"name", "offset", "abbreviation", "dst"})
+	for _, z := range zones {
+		_ = cw.Write([]string{z.Name, z.Offset, z.Abbreviation, strconv.FormatBool(z.DST)})
+	}
+	cw.Flush()
+	return cw.Error() //nolint:wrapcheck // write errors are reported as is
+}
@@ -6,20 +6,29 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 	"strings"
 	"time"
+	_ "time/tzdata" // zone database fallback for minimal containers
 
 	"github.com/sgaunet/calcdate/v2"
 )
 
-
 var version = "development"
 
 // errNoExpressionProvided is returned when no expression is provided via stdin.
 var errNoExpressionProvided = errors.New("no expression provided via stdin")
 
+// errNoMatch is returned when --where filtered out every result. Like grep,
+// calcdate then exits with status 1 without a message.
+var errNoMatch = errors.New("no result matches the predicate")
+
+// errIterationModes is returned when more than one of --each, --cron and
+// --rrule is given.
+var errIterationModes = errors.New("only one of -each, -cron and -rrule can be given")
+
 func printVersion() {
 	fmt.Println(version)
 }
@@ -53,10 +62,20 @@ func readExprFromStdin() (string, error) {
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		if subcommand, ok := subcommands[os.Args[1]]; ok {
+			exitOnError(subcommand(os.Args[2:], os.Stdout))
+			os.Exit(0)
+		}
+	}
+
 	config := parseCommandLineFlags()
 
 	if config.listTZ {
-		calcdate.ListTZ()
+		if err := listTimezones(config, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
 		os.Exit(0)
 	}
 
@@ -65,6 +84,15 @@ func main() {
 		os.Exit(0)
 	}
 
+	if config.batch {
+		r, err := newRunner(config, os.Stdout)
+		if err == nil {
+			err = r.runBatch(os.Stdin, os.Stderr)
+		}
+		exitOnError(err)
+		return
+	}
+
 	// Handle expression syntax (required via parameter or stdin)
 	if config.expr == "" {
 		// Check if stdin is redirected (piped or from file)
@@ -83,13 +111,57 @@ func main() {
 		}
 	}
 
-	processExpressionMode(config.expr, config.each, config.transform, config.format, config.tz, config.skipWeekends)
+	r, err := newRunner(config, os.Stdout)
+	if err == nil {
+		err = r.run(config.expr)
+	}
+	exitOnError(err)
+}
+
+// subcommands maps the first argument to a subcommand taking the remaining arguments.
+var subcommands = map[string]func(args []string, stdout io.Writer) error{
+	"bucket":     runBucket,
+	"cal":        runCal,
+	"cron":       runCron,
+	"dst":        runDST,
+	"grep":       runGrep,
+	"map":        runMap,
+	"partitions": runPartitions,
+	"retain":     runRetain,
+}
+
+// exitOnError prints err and exits with status 1. A subcommand's -h has
+// already printed its usage and is not an error.
+func exitOnError(err error) {
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if !errors.Is(err, errNoMatch) {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+	}
+	os.Exit(1)
 }
 
 type cliConfig struct {
-	tz                                            string
-	expr, each, transform, format                 string
-	vOption, listTZ, skipWeekends                 bool
+	tz, outTZ                     string
+	expr, each, transform, format string
+	vOption, listTZ, skipWeekends bool
+	natural, explain              bool
+	locale, zones, output         string
+	tzAbbrevPolicy, dstPolicy     string
+	monthOverflow                 string
+	weekStart, weekend            string
+	fiscalYearStart               string
+	retailPattern, retailYearEnd  string
+	cron, rrule, exdate           string
+	icsSummary, icsDescription    string
+	alignWeeks                    bool
+	tzSearch, tzRegion, tzOffset  string
+	where                         string
+	calendars                     listFlag
+	subtract                      string
+	sqlColumn, sqlDialect         string
+	batch, keepGoing, echo        bool
 }
 
 func parseCommandLineFlags() cliConfig {
@@ -97,7 +169,19 @@ func parseCommandLineFlags() cliConfig {
 
 	// Legacy flags (kept)
 	flag.StringVar(&config.tz, "tz", "Local", "Input timezone")
-	flag.BoolVar(&config.listTZ, "list-tz", false, "List timezones")
+	flag.StringVar(&config.outTZ, "out-tz", "",
+		"Output timezone (default: the input timezone, or the zone set by a 'tz' operation)")
+	flag.StringVar(&config.tzAbbrevPolicy, "tz-abbrev-policy", "first",
+		"Resolution of ambiguous zone abbreviations like IST: first, strict, or a region "+
+			"(america, europe, asia, middle-east, africa, australia, pacific)")
+	flag.StringVar(&config.dstPolicy, "dst-policy", "later",
+		"Resolution of local times skipped or repeated by DST: earlier, later, shift-forward, error")
+	flag.BoolVar(&config.listTZ, "list-tz", false,
+		"List timezones with their current offset, abbreviation and DST flag (text, json or csv with -output)")
+	flag.StringVar(&config.tzSearch, "tz-search", "", "With -list-tz, keep zones whose name contains this text")
+	flag.StringVar(&config.tzRegion, "tz-region", "", "With -list-tz, keep zones of this region (e.g., 'Europe')")
+	flag.StringVar(&config.tzOffset, "tz-offset", "",
+		"With -list-tz, keep zones currently at this UTC offset (e.g., '+02:00', '-5', '+0530')")
 	flag.BoolVar(&config.vOption, "v", false, "Get version")
 
 	// New expression flags
@@ -105,156 +189,394 @@ func parseCommandLineFlags() cliConfig {
 		"Date expression (e.g., 'today +1d', 'now | +2h | round hour', 'today...+7d')")
 	flag.StringVar(&config.expr, "x", "", "Date expression (short form)")
 	flag.StringVar(&config.each, "each", "", "Iteration interval for ranges (e.g., '1d', '1w', '1M')")
+	flag.StringVar(&config.cron, "cron", "",
+		"Iterate a range over the fire times of a cron schedule in -tz (e.g., '0 9 * * 1-5', '@daily')")
+	flag.StringVar(&config.rrule, "rrule", "",
+		"Iterate a range over the occurrences of an RFC 5545 recurrence rule starting at the range start "+
+			"(e.g., 'FREQ=MONTHLY;BYDAY=2TU;COUNT=12')")
+	flag.StringVar(&config.exdate, "exdate", "",
+		"Comma-separated occurrences to exclude from -rrule (e.g., '2025-07-08,20250812T090000')")
+	flag.StringVar(&config.monthOverflow, "month-overflow", "overflow",
+		"Adding months to a day missing from the target month (2024-01-31 +1M): clamp, overflow, error")
 	flag.StringVar(&config.transform, "transform", "",
 		"Transform expression for iterations (e.g., '$begin +8h, $end +20h')")
 	flag.StringVar(&config.transform, "t", "", "Transform expression (short form)")
 	flag.StringVar(&config.format, "format", "",
 		"Output format: iso, sql, ts, human, compact, or Unix date format "+
-		"(e.g., '%Y-%m-%d %H:%M:%S', '%Y-%m-%d %H:%M:%S %Z')")
+			"(e.g., '%Y-%m-%d %H:%M:%S', '%Y-%m-%d %H:%M:%S %Z')")
 	flag.StringVar(&config.format, "f", "", "Output format (short form)")
-	flag.BoolVar(&config.skipWeekends, "skip-weekends", false, "Skip weekend days in iterations")
+	flag.BoolVar(&config.skipWeekends, "skip-weekends", false, "Skip weekend days (see -weekend) in iterations")
+	flag.StringVar(&config.weekStart, "week-start", "monday",
+		"First day of the week for startOfWeek/endOfWeek and -align-weeks (e.g., 'sunday', or 'dimanche' with -locale fr)")
+	flag.StringVar(&config.weekend, "weekend", "sat,sun",
+		"Comma-separated weekend days for -skip-weekends and isWeekend/isWorkday (e.g., 'fri,sat', or 'none')")
+	flag.StringVar(&config.fiscalYearStart, "fiscal-year-start", "january",
+		"First month of the fiscal year for fiscal operations, -each fq/fY and %FY/%FQ (e.g., 'april' or '4')")
+	flag.StringVar(&config.retailPattern, "retail-pattern", "4-4-5",
+		"Weeks per period of a retail quarter for startOfPeriod/endOfPeriod and -each rp: 4-4-5, 4-5-4, 5-4-4")
+	flag.StringVar(&config.retailYearEnd, "retail-year-end", "last",
+		"Saturday ending the retail year: last (last Saturday of January) or nearest (nearest to January 31)")
+	flag.BoolVar(&config.alignWeeks, "align-weeks", false,
+		"Align iterations of whole days (e.g., -each 1w) on the week start")
+	flag.BoolVar(&config.natural, "natural", false,
+		"Accept natural-language phrases (e.g., '3 days ago', 'in 2 weeks', 'next monday at 9am')")
+	flag.BoolVar(&config.explain, "explain", false, "Print the canonical expression instead of evaluating it")
+	flag.BoolVar(&config.batch, "batch", false,
+		"Evaluate every stdin line as an expression, with the same now, timezone and format")
+	flag.BoolVar(&config.keepGoing, "keep-going", false,
+		"With -batch, report failing lines and continue (exit status 1 at the end) instead of stopping")
+	flag.BoolVar(&config.echo, "echo", false, "With -batch, print each result as 'input<TAB>output'")
+	flag.StringVar(&config.locale, "locale", "en",
+		"Locale for month/weekday names in input and output: en, fr, de, es")
+	flag.StringVar(&config.zones, "zones", "",
+		"Show results in several timezones, one column per zone (e.g., 'Europe/Paris,America/New_York')")
+	flag.StringVar(&config.where, "where", "",
+		"Only print results matching a predicate, e.g. 'isAmbiguous or isNonexistent' "+
+			"(exit status 1 when nothing matches)")
+	flag.Var(&config.calendars, "calendar",
+		"Named iCalendar file for nextEvent/prevEvent, isInEvent and -subtract "+
+			"(e.g., 'freeze=freeze.ics'; repeatable)")
+	flag.StringVar(&config.subtract, "subtract", "",
+		"Comma-separated calendars (see -calendar) whose events are cut out of iterations (e.g., 'freeze,holidays')")
+	flag.StringVar(&config.output, "output", "text", "Output type: text, json, csv, ics, sql-where")
+	flag.StringVar(&config.output, "o", "text", "Output type (short form)")
+	flag.StringVar(&config.icsSummary, "ics-summary", "",
+		"SUMMARY of -output ics events; $begin, $end, $index and $expr are replaced "+
+			"(default: '$begin - $end', or '$begin' for single dates)")
+	flag.StringVar(&config.icsDescription, "ics-description", "",
+		"DESCRIPTION of -output ics events; $begin, $end, $index and $expr are replaced")
+	flag.StringVar(&config.sqlColumn, "sql-column", "created_at",
+		"Column compared by -output sql-where predicates, an optionally dotted identifier (e.g., 'orders.created_at')")
+	flag.StringVar(&config.sqlDialect, "sql-dialect", "postgres",
+		"Timestamp literals of -output sql-where: postgres, mysql, sqlite, clickhouse, bigquery")
 
 	flag.Parse()
 	return config
 }
 
+// failure prefixes err with a user-facing message such as "Failed to parse expression".
+func failure(msg string, err error) error {
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// runner holds the settings shared by every evaluation of one invocation.
+type runner struct {
+	config cliConfig
+	tz     *time.Location
+	outTZ  *time.Location
+	// keepZone leaves results in the location chosen by a "tz"/"utc" operation.
+	keepZone     bool
+	zones        []*time.Location
+	abbrevPolicy calcdate.TZAbbrevPolicy
+	dstPolicy    calcdate.DSTPolicy
+	monthPolicy  calcdate.MonthOverflowPolicy
+	week         calcdate.Week
+	fiscal       calcdate.FiscalCalendar
+	retail       calcdate.RetailCalendar
+	calendars    map[string]*calcdate.Calendar
+	locale       *calcdate.Locale
+	formatter    calcdate.Formatter
+	stdout       io.Writer
+	// now is the current time of every evaluation of the invocation.
+	now time.Time
+	out resultWriter
+	// echo prefixes the output lines with the batch input line.
+	echo  *prefixWriter
+	where *calcdate.Predicate
+	// matched counts the results kept by where
+	matched int
+}
+
+func newRunner(config cliConfig, stdout io.Writer) (*runner, error) {
+	r := &runner{config: config, stdout: stdout}
 
-// processExpressionMode handles the new expression syntax.
-func processExpressionMode(expr, each, transform, format, tzStr string, skipWeekends bool) {
 	// Parse timezone
-	var tz *time.Location
-	var err error
-	if tzStr != "" {
-		tz, err = time.LoadLocation(tzStr)
+	if config.tz != "" {
+		tz, err := time.LoadLocation(config.tz)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Invalid timezone: %v\n", err)
-			os.Exit(1)
+			return nil, failure("Invalid timezone", err)
 		}
+		r.tz = tz
 	} else {
-		tz = time.Local //nolint:gosmopolitan // intentional default to local timezone
+		r.tz = time.Local //nolint:gosmopolitan // intentional default to local timezone
+	}
+
+	if config.outTZ != "" {
+		outTZ, err := calcdate.LoadTimezone(config.outTZ)
+		if err != nil {
+			return nil, failure("Invalid output timezone", err)
+		}
+		r.outTZ = outTZ
+	}
+
+	if config.zones != "" {
+		zones, err := calcdate.ParseZoneList(config.zones)
+		if err != nil {
+			return nil, failure("Invalid zones", err)
+		}
+		r.zones = zones
+	}
+
+	policy, err := calcdate.ParseTZAbbrevPolicy(config.tzAbbrevPolicy)
+	if err != nil {
+		return nil, failure("Invalid timezone abbreviation policy", err)
+	}
+	r.abbrevPolicy = policy
+
+	r.dstPolicy, err = calcdate.ParseDSTPolicy(config.dstPolicy)
+	if err != nil {
+		return nil, failure("Invalid DST policy", err)
+	}
+
+	r.monthPolicy, err = calcdate.ParseMonthOverflowPolicy(config.monthOverflow)
+	if err != nil {
+		return nil, failure("Invalid month overflow policy", err)
+	}
+
+	if config.where != "" {
+		where, err := calcdate.ParsePredicate(config.where)
+		if err != nil {
+			return nil, failure("Invalid predicate", err)
+		}
+		r.where = &where
+	}
+
+	locale, err := calcdate.LookupLocale(config.locale)
+	if err != nil {
+		return nil, failure("Invalid locale", err)
+	}
+	r.locale = locale
+
+	if config.fiscalYearStart != "" {
+		r.fiscal.StartMonth, err = calcdate.ParseFiscalYearStart(config.fiscalYearStart, locale)
+		if err != nil {
+			return nil, failure("Invalid fiscal year start", err)
+		}
+	}
+	r.formatter = calcdate.Formatter{Locale: locale, Fiscal: r.fiscal}
+
+	r.retail.Pattern, err = calcdate.ParseRetailPattern(config.retailPattern)
+	if err != nil {
+		return nil, failure("Invalid retail pattern", err)
+	}
+	r.retail.YearEnd, err = calcdate.ParseRetailYearEnd(config.retailYearEnd)
+	if err != nil {
+		return nil, failure("Invalid retail year end", err)
+	}
+
+	r.week = calcdate.ISOWeek
+	if config.weekStart != "" {
+		r.week.Start, err = calcdate.ParseWeekStart(config.weekStart, locale)
+		if err != nil {
+			return nil, failure("Invalid week start", err)
+		}
+	}
+	if config.weekend != "" {
+		r.week.Weekend, err = calcdate.ParseWeekdaySet(config.weekend, locale)
+		if err != nil {
+			return nil, failure("Invalid weekend days", err)
+		}
+	}
+
+	if err := r.loadCalendars(); err != nil {
+		return nil, failure("Invalid calendar", err)
+	}
+
+	now := time.Now()
+	r.now = now
+	if config.echo {
+		r.echo = &prefixWriter{w: stdout, atLineStart: true}
+		r.stdout = r.echo
+	}
+	// ics events and SQL literals are written in the output zone
+	exportLoc := r.tz
+	if r.outTZ != nil {
+		exportLoc = r.outTZ
+	}
+	dialect, err := calcdate.ParseSQLDialect(config.sqlDialect)
+	if err != nil {
+		return nil, failure("Invalid output", err)
+	}
+	r.out, err = newResultWriter(config.output, r.stdout, columns{
+		zones:  r.zones,
+		now:    now,
+		format: r.formatIn,
+	}, icsSettings{
+		expr:        strings.TrimSpace(config.expr),
+		summary:     config.icsSummary,
+		description: config.icsDescription,
+		loc:         exportLoc,
+		format:      r.formatIn,
+		now:         now,
+	}, sqlSettings{
+		column:  config.sqlColumn,
+		dialect: dialect,
+		loc:     exportLoc,
+	})
+	if err != nil {
+		return nil, failure("Invalid output", err)
+	}
+
+	return r, nil
+}
+
+// newContext returns a fresh evaluation context for the invocation.
+func (r *runner) newContext() *calcdate.EvalContext {
+	return &calcdate.EvalContext{
+		Now:             r.now,
+		Timezone:        r.tz,
+		AbbrevPolicy:    r.abbrevPolicy,
+		DSTPolicy:       r.dstPolicy,
+		MonthOverflow:   r.monthPolicy,
+		Week:            &r.week,
+		FiscalYearStart: r.fiscal.StartMonth,
+		Retail:          r.retail,
+		Calendars:       r.calendars,
+	}
+}
+
+// run evaluates the expression and writes its results.
+func (r *runner) run(expr string) error {
+	if err := r.processExpressionMode(expr); err != nil {
+		return err
+	}
+	if err := r.out.flush(); err != nil {
+		return err
+	}
+	if r.where != nil && r.matched == 0 && !r.config.explain {
+		return errNoMatch
+	}
+	return nil
+}
+
+// keep reports whether a result, evaluated in ctx, passes --where.
+func (r *runner) keep(t time.Time, status calcdate.LocalTimeStatus, ctx *calcdate.EvalContext) bool {
+	if r.where != nil && !r.where.Match(t, status, ctx) {
+		return false
 	}
+	r.matched++
+	return true
+}
 
+// keepRange reports whether a range row passes --where, testing its begin.
+func (r *runner) keepRange(begin time.Time) bool {
+	return r.keep(begin, calcdate.CheckLocalTime(begin, begin.Location()), r.newContext())
+}
+
+// processExpressionMode handles the new expression syntax.
+func (r *runner) processExpressionMode(expr string) error {
 	// Parse the expression
 	parser := calcdate.NewExprParser(expr)
+	parser.Natural = r.config.natural
+	parser.Locale = r.locale
 	node, err := parser.Parse(expr)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to parse expression: %v\n", err)
-		os.Exit(1)
+		return failure("Failed to parse expression", err)
 	}
 
+	if r.config.explain {
+		_, err := fmt.Fprintln(r.stdout, node)
+		return err //nolint:wrapcheck // write errors are reported as is
+	}
+	r.keepZone = calcdate.HasTimezoneOperation(node)
+
 	// Check if it's a range expression (directly or within a pipe)
 	if rangeNode, ok := node.(*calcdate.RangeNode); ok {
-		processRangeExpression(rangeNode, each, transform, format, tz, skipWeekends)
-		return
+		return r.processRangeExpression(rangeNode)
 	}
 
 	// Check if it's a pipe with a range as base
 	if pipeNode, ok := node.(*calcdate.PipeNode); ok {
 		if rangeNode, ok := pipeNode.Base.(*calcdate.RangeNode); ok {
 			// This is a range with pipeline operations
-			processRangeWithPipeline(rangeNode, pipeNode.Operations, each, transform, format, tz, skipWeekends)
-			return
+			return r.processRangeWithPipeline(rangeNode, pipeNode.Operations)
 		}
 	}
 
 	// Single date expression
-	ctx := &calcdate.EvalContext{
-		Now:      time.Now(),
-		Timezone: tz,
-	}
-
-	result, err := node.Evaluate(ctx)
+	ctx := r.newContext()
+	result, status, err := calcdate.EvaluateLocalTime(node, ctx)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to evaluate expression: %v\n", err)
-		os.Exit(1)
+		return failure("Failed to evaluate expression", err)
 	}
 
-	// Format and print the result
-	output := formatOutput(result, format, tz)
-	fmt.Println(output)
-}
-
-// processRangeWithPipeline handles range expressions with pipeline operations
-//
-//nolint:lll // long function signature is readable
-func processRangeWithPipeline(rangeNode *calcdate.RangeNode, operations []calcdate.ExprNode, each, transform, format string, tz *time.Location, skipWeekends bool) {
-	ctx := &calcdate.EvalContext{
-		Now:      time.Now(),
-		Timezone: tz,
-	}
-
-	// Evaluate start and end
-	start, err := rangeNode.Start.Evaluate(ctx)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to evaluate range start: %v\n", err)
-		os.Exit(1)
+	if !r.keep(result, status, ctx) {
+		return nil
 	}
+	return r.out.writeDate(result)
+}
 
-	end, err := rangeNode.End.Evaluate(ctx)
+// processRangeWithPipeline handles range expressions with pipeline operations.
+func (r *runner) processRangeWithPipeline(rangeNode *calcdate.RangeNode, operations []calcdate.ExprNode) error {
+	start, end, err := r.evaluateRange(rangeNode)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to evaluate range end: %v\n", err)
-		os.Exit(1)
+		return err
 	}
 
 	// Apply pipeline operations to the end date
-	for _, op := range operations {
-		if opNode, ok := op.(*calcdate.OperationNode); ok {
-			end, err = calcdate.ApplyOperation(end, opNode.Op, opNode.Value, tz)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to apply operation: %v\n", err)
-				os.Exit(1)
-			}
-		} else {
-			fmt.Fprintf(os.Stderr, "Unknown operation type\n")
-			os.Exit(1)
-		}
+	end, err = calcdate.ApplyOperationsWithContext(end, operations, r.newContext())
+	if err != nil {
+		return failure("Failed to apply operation", err)
 	}
 
 	// Continue with the rest of the range processing
-	processRangeExpressionInternal(start, end, each, transform, format, tz, skipWeekends)
+	return r.processRangeExpressionInternal(start, end)
 }
 
-// processRangeExpression handles range expressions with optional iterations
-//
-//nolint:lll // long function signature is readable
-func processRangeExpression(rangeNode *calcdate.RangeNode, each, transform, format string, tz *time.Location, skipWeekends bool) {
-	ctx := &calcdate.EvalContext{
-		Now:      time.Now(),
-		Timezone: tz,
+// processRangeExpression handles range expressions with optional iterations.
+func (r *runner) processRangeExpression(rangeNode *calcdate.RangeNode) error {
+	start, end, err := r.evaluateRange(rangeNode)
+	if err != nil {
+		return err
 	}
 
-	// Evaluate start and end
+	// Continue with common processing
+	return r.processRangeExpressionInternal(start, end)
+}
+
+// evaluateRange evaluates the start and end of a range.
+func (r *runner) evaluateRange(rangeNode *calcdate.RangeNode) (time.Time, time.Time, error) {
+	ctx := r.newContext()
+
 	start, err := rangeNode.Start.Evaluate(ctx)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to evaluate range start: %v\n", err)
-		os.Exit(1)
+		return time.Time{}, time.Time{}, failure("Failed to evaluate range start", err)
 	}
 
 	end, err := rangeNode.End.Evaluate(ctx)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to evaluate range end: %v\n", err)
-		os.Exit(1)
+		return time.Time{}, time.Time{}, failure("Failed to evaluate range end", err)
 	}
-
-	// Continue with common processing
-	processRangeExpressionInternal(start, end, each, transform, format, tz, skipWeekends)
+	return start, end, nil
 }
 
-// processRangeExpressionInternal handles the common logic for range processing
-//
-//nolint:lll // long function signature is readable
-func processRangeExpressionInternal(start, end time.Time, each, transform, format string, tz *time.Location, skipWeekends bool) {
-	transformNode, err := parseTransformIfProvided(transform)
+// processRangeExpressionInternal handles the common logic for range processing.
+func (r *runner) processRangeExpressionInternal(start, end time.Time) error {
+	transformNode, err := parseTransformIfProvided(r.config.transform)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to parse transform: %v\n", err)
-		os.Exit(1)
+		return failure("Failed to parse transform", err)
 	}
 
-	if each != "" {
-		processIterations(start, end, each, transformNode, format, tz, skipWeekends)
-	} else {
-		processSingleRange(start, end, transformNode, format, tz)
+	modes := 0
+	for _, mode := range []string{r.config.each, r.config.cron, r.config.rrule} {
+		if mode != "" {
+			modes++
+		}
+	}
+	if modes > 1 {
+		return failure("Failed to iterate", errIterationModes)
+	}
+
+	if r.config.cron != "" {
+		return r.processCron(start, end, transformNode)
 	}
+	if r.config.rrule != "" {
+		return r.processRRule(start, end, transformNode)
+	}
+	if r.config.each != "" {
+		return r.processIterations(start, end, transformNode)
+	}
+	return r.processSingleRange(start, end, transformNode)
 }
 
 func parseTransformIfProvided(transform string) (*calcdate.TransformNode, error) {
@@ -270,79 +592,165 @@ func parseTransformIfProvided(transform string) (*calcdate.TransformNode, error)
 	return node, nil
 }
 
-//nolint:lll // long function signature is readable
-func processIterations(start, end time.Time, each string, transformNode *calcdate.TransformNode, format string, tz *time.Location, skipWeekends bool) {
-	if isSpecialInterval(each) {
-		processSpecialIntervalIterations(start, end, each, transformNode, format, tz, skipWeekends)
-	} else {
-		processRegularIntervalIterations(start, end, each, transformNode, format, tz, skipWeekends)
+func (r *runner) processIterations(start, end time.Time, transformNode *calcdate.TransformNode) error {
+	results, err := r.iterate(start, end, transformNode)
+	if err != nil {
+		return err
+	}
+	if results, err = r.subtractEvents(results); err != nil {
+		return err
 	}
+	return r.printFilteredResults(results)
 }
 
-func isSpecialInterval(each string) bool {
-	return strings.HasSuffix(each, "M") || strings.HasSuffix(each, "Y") || strings.HasSuffix(each, "q")
+// iterate splits the range into --each intervals.
+func (r *runner) iterate(
+	start, end time.Time, transformNode *calcdate.TransformNode,
+) ([]calcdate.IterationResult, error) {
+	if !calcdate.IsCalendarInterval(r.config.each) {
+		return r.iterateRegularInterval(start, end, transformNode)
+	}
+	results, err := calcdate.IterateWithSpecialIntervalContext(start, end, r.config.each, transformNode, r.newContext())
+	if err != nil {
+		return nil, failure("Failed to iterate", err)
+	}
+	return results, nil
 }
 
-//nolint:lll // long function signature is readable
-func processSpecialIntervalIterations(start, end time.Time, each string, transformNode *calcdate.TransformNode, format string, tz *time.Location, skipWeekends bool) {
-	results, err := calcdate.IterateWithSpecialInterval(start, end, each, transformNode, tz)
+// processCron prints the fire times of --cron within the range, or the rows
+// built from them by the transform.
+func (r *runner) processCron(start, end time.Time, transformNode *calcdate.TransformNode) error {
+	schedule, err := calcdate.ParseCron(r.config.cron)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to iterate: %v\n", err)
-		os.Exit(1)
+		return failure("Invalid cron schedule", err)
+	}
+	results, err := calcdate.IterateCron(start.In(r.tz), end, schedule, transformNode, r.newContext())
+	if err != nil {
+		return failure("Failed to iterate", err)
+	}
+	if results, err = r.subtractEvents(results); err != nil {
+		return err
 	}
-	printFilteredResults(results, format, tz, skipWeekends)
+	return r.printOccurrences(results, transformNode)
 }
 
-//nolint:lll // long function signature is readable
-func processRegularIntervalIterations(start, end time.Time, each string, transformNode *calcdate.TransformNode, format string, tz *time.Location, skipWeekends bool) {
-	interval, err := calcdate.ParseInterval(each)
+// processRRule prints the occurrences of --rrule from the range start (its
+// DTSTART) to the range end, or the rows built from them by the transform.
+func (r *runner) processRRule(start, end time.Time, transformNode *calcdate.TransformNode) error {
+	rule, err := calcdate.ParseRRule(r.config.rrule, r.tz)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to parse interval: %v\n", err)
-		os.Exit(1)
+		return failure("Invalid recurrence rule", err)
+	}
+	if err := rule.AddExDates(r.config.exdate, r.tz); err != nil {
+		return failure("Invalid recurrence rule", err)
+	}
+	results, err := calcdate.IterateRRule(start.In(r.tz), end, rule, transformNode, r.newContext())
+	if err != nil {
+		return failure("Failed to iterate", err)
+	}
+	if results, err = r.subtractEvents(results); err != nil {
+		return err
+	}
+	return r.printOccurrences(results, transformNode)
+}
+
+// printOccurrences prints cron fire times or recurrences: one date per
+// line, or one range per line when a transform widened them.
+func (r *runner) printOccurrences(results []calcdate.IterationResult, transformNode *calcdate.TransformNode) error {
+	if transformNode != nil {
+		return r.printFilteredResults(results)
 	}
+	for _, result := range results {
+		if r.config.skipWeekends && r.week.IsWeekend(result.BeginTime) {
+			continue
+		}
+		if !r.keepRange(result.BeginTime) {
+			continue
+		}
+		if err := r.out.writeDate(result.BeginTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	iterator := calcdate.NewRangeIterator(start, end, interval, transformNode, tz)
+func (r *runner) iterateRegularInterval(
+	start, end time.Time, transformNode *calcdate.TransformNode,
+) ([]calcdate.IterationResult, error) {
+	interval, err := calcdate.ParseInterval(r.config.each)
+	if err != nil {
+		return nil, failure("Failed to parse interval", err)
+	}
+
+	iterator := calcdate.NewRangeIterator(start, end, interval, transformNode, r.tz)
+	iterator.Context = r.newContext()
+	if r.config.alignWeeks {
+		iterator.Week = &r.week
+	}
 	results, err := iterator.Iterate()
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to iterate: %v\n", err)
-		os.Exit(1)
+		return nil, failure("Failed to iterate", err)
 	}
-	printFilteredResults(results, format, tz, skipWeekends)
+	return results, nil
 }
 
-func printFilteredResults(results []calcdate.IterationResult, format string, tz *time.Location, skipWeekends bool) {
+func (r *runner) printFilteredResults(results []calcdate.IterationResult) error {
 	for _, result := range results {
-		if skipWeekends && isWeekend(result.BeginTime) {
+		if r.config.skipWeekends && r.week.IsWeekend(result.BeginTime) {
+			continue
+		}
+		if !r.keepRange(result.BeginTime) {
 			continue
 		}
-		printIterationResult(result, format, tz)
+		if err := r.out.writeRange(result.BeginTime, result.EndTime); err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
-func processSingleRange(start, end time.Time, transformNode *calcdate.TransformNode, format string, tz *time.Location) {
+func (r *runner) processSingleRange(start, end time.Time, transformNode *calcdate.TransformNode) error {
 	if transformNode != nil {
-		ctx := &calcdate.EvalContext{
-			Now:      time.Now(),
-			Timezone: tz,
-		}
 		var err error
-		start, end, err = calcdate.EvaluateTransform(transformNode, start, end, 0, ctx)
+		start, end, err = calcdate.EvaluateTransform(transformNode, start, end, 0, r.newContext())
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to apply transform: %v\n", err)
-			os.Exit(1)
+			return failure("Failed to apply transform", err)
 		}
 	}
-	startStr := formatOutput(start, format, tz)
-	endStr := formatOutput(end, format, tz)
-	fmt.Printf("%s - %s\n", startStr, endStr)
+	if !r.keepRange(start) {
+		return nil
+	}
+	return r.out.writeRange(start, end)
 }
 
-// formatOutput formats a time according to the specified format.
-func formatOutput(t time.Time, format string, tz *time.Location) string {
-	if tz != nil {
-		t = t.In(tz)
+// formatIn formats t in loc, or in the default output zone when loc is nil.
+func (r *runner) formatIn(t time.Time, loc *time.Location) string {
+	if loc != nil {
+		return r.formatTime(t.In(loc))
 	}
+	return r.formatOutput(t)
+}
 
+// formatOutput formats a time in the output zone according to the specified format.
+func (r *runner) formatOutput(t time.Time) string {
+	switch {
+	case r.outTZ != nil:
+		t = t.In(r.outTZ)
+	case r.keepZone:
+		// The expression chose its own output zone with "tz" or "utc"
+	case r.tz != nil:
+		t = t.In(r.tz)
+	}
+	return r.formatTime(t)
+}
+
+// formatTime formats a time according to the specified format.
+func (r *runner) formatTime(t time.Time) string {
+	return r.formatTimeAs(t, r.config.format)
+}
+
+// formatTimeAs renders t with a -format value.
+func (r *runner) formatTimeAs(t time.Time, format string) string {
 	switch format {
 	case "iso":
 		return t.Format(time.RFC3339)
@@ -351,7 +759,7 @@ func formatOutput(t time.Time, format string, tz *time.Location) string {
 	case "ts":
 		return strconv.FormatInt(t.Unix(), 10)
 	case "human":
-		return t.Format("Monday, January 2, 2006")
+		return r.formatter.Human(t)
 	case "compact":
 		return t.Format("20060102")
 	case "":
@@ -360,25 +768,9 @@ func formatOutput(t time.Time, format string, tz *time.Location) string {
 	default:
 		// Check if this is a Unix date format (contains %)
 		if strings.Contains(format, "%") {
-			// Convert Unix format to Go format
-			goFormat := calcdate.ConvertUnixFormatToGolang(format)
-			return t.Format(goFormat)
+			return r.formatter.Format(t, format)
 		}
 		// Otherwise treat as Go format (backward compatibility)
 		return t.Format(format)
 	}
 }
-
-// printIterationResult prints a single iteration result.
-func printIterationResult(result calcdate.IterationResult, format string, tz *time.Location) {
-	beginStr := formatOutput(result.BeginTime, format, tz)
-	endStr := formatOutput(result.EndTime, format, tz)
-	fmt.Printf("%s - %s\n", beginStr, endStr)
-}
-
-// isWeekend checks if a date is a weekend.
-func isWeekend(t time.Time) bool {
-	weekday := t.Weekday()
-	return weekday == time.Saturday || weekday == time.Sunday
-}
-
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sgaunet/calcdate/v2"
+)
+
+// Errors of "calcdate map".
+var (
+	errMapUsage = errors.New(
+		"usage: calcdate map (--csv|--tsv --column=COLUMN | --match=REGEXP) [--pipe='| OPERATIONS'] [--input-format=LAYOUT]... < FILE")
+	errUnknownColumn = errors.New("unknown column")
+	errMapFailed     = errors.New("values failed")
+)
+
+// runMap implements "calcdate map": it copies a CSV or TSV stream, or log
+// lines, from stdin to stdout, replacing the dates of one column (or the
+// first match of a regular expression) by the result of pipeline operations.
+//
+//	calcdate map --csv --column=created_at --pipe '| tz UTC | trunc hour' < events.csv
+//	calcdate map --tsv --no-header --column=3 --input-format '%d/%m/%Y %H:%M' -f iso < export.tsv
+//	calcdate map --match '^\[([^]]+)\]' --input-format iso --pipe '| utc' < app.log
+func runMap(args []string, stdout io.Writer) error {
+	var config cliConfig
+	var csvMode, tsvMode, noHeader bool
+	var column, match, pipe string
+	var layouts listFlag
+
+	flags := flag.NewFlagSet("map", flag.ContinueOnError)
+	flags.BoolVar(&csvMode, "csv", false, "Read and write comma-separated values")
+	flags.BoolVar(&tsvMode, "tsv", false, "Read and write tab-separated values")
+	flags.StringVar(&column, "column", "", "Column to map, by header name or 1-based number")
+	flags.BoolVar(&noHeader, "no-header", false, "The first row is data, not a header (-column must be a number)")
+	flags.StringVar(&match, "match", "",
+		"Map the first match of a regular expression in each line, or its first group (e.g., '^\\[([^]]+)\\]')")
+	flags.StringVar(&pipe, "pipe", "", "Operations applied to each date (e.g., '| tz UTC | trunc hour')")
+	flags.Var(&layouts, "input-format",
+		"Input layout: iso, sql, ts, compact, Unix or Go format; the first match wins (repeatable; default: any date calcdate reads)")
+	flags.StringVar(&config.tz, "tz", "Local", "Timezone of the dates without an offset")
+	flags.StringVar(&config.format, "format", "",
+		"Output format (same values as calcdate -format; default: the matching -input-format, else sql)")
+	flags.StringVar(&config.format, "f", "", "Output format (short form)")
+	flags.BoolVar(&config.keepGoing, "keep-going", false,
+		"Report dates that cannot be read or mapped and leave them unchanged (exit status 1 at the end) instead of stopping")
+	if err := flags.Parse(args); err != nil {
+		return err //nolint:wrapcheck // flag errors are already printed with the usage
+	}
+	modes := 0
+	for _, set := range []bool{csvMode, tsvMode, match != ""} {
+		if set {
+			modes++
+		}
+	}
+	if modes != 1 || (match == "" && column == "") || flags.NArg() > 0 {
+		return errMapUsage
+	}
+	config.locale = "en"
+
+	r, err := newRunner(config, stdout)
+	if err != nil {
+		return err
+	}
+	m := &columnMapper{r: r, layouts: layouts, stderr: os.Stderr}
+	if pipe != "" {
+		if m.operations, err = calcdate.ParsePipeline(pipe); err != nil {
+			return failure("Failed to parse pipeline", err)
+		}
+	}
+
+	switch {
+	case match != "":
+		re, err := regexp.Compile(match)
+		if err != nil {
+			return failure("Invalid -match", err)
+		}
+		err = m.mapLines(os.Stdin, stdout, re)
+	case tsvMode:
+		err = m.mapCSV(os.Stdin, stdout, '\t', column, !noHeader)
+	default:
+		err = m.mapCSV(os.Stdin, stdout, ',', column, !noHeader)
+	}
+	if err != nil {
+		return err
+	}
+	if m.failed > 0 {
+		return fmt.Errorf("%d of %d %w", m.failed, m.mapped, errMapFailed)
+	}
+	return nil
+}
+
+// columnMapper parses, transforms and formats the dates of a column.
+type columnMapper struct {
+	r              *runner
+	layouts        []string
+	operations     []calcdate.ExprNode
+	stderr         io.Writer
+	mapped, failed int
+}
+
+// mapCSV streams CSV records, mapping the given column. Everything but the
+// mapped fields is copied byte for byte: quoting, line endings, blank lines.
+// Rows too short to have the column are copied unchanged.
+func (m *columnMapper) mapCSV(stdin io.Reader, stdout io.Writer, comma rune, column string, header bool) error {
+	// The reader reads ahead: raw holds the input from the current record on
+	var raw bytes.Buffer
+	reader := csv.NewReader(io.TeeReader(stdin, &raw))
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	writer := bufio.NewWriter(stdout)
+	defer writer.Flush()
+
+	index := -1
+	if n, err := strconv.Atoi(column); err == nil && n > 0 {
+		index = n - 1
+	}
+	var offset int64
+	line := 1
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return failure("Invalid input", err)
+		}
+		chunk := csvChunk{raw: raw.Next(int(reader.InputOffset() - offset)), line: line, comma: comma}
+		offset = reader.InputOffset()
+		line += bytes.Count(chunk.raw, []byte("\n"))
+
+		out := chunk.raw
+		if first && header {
+			if index < 0 {
+				names := slices.Clone(record)
+				names[0] = strings.TrimPrefix(names[0], "\ufeff")
+				index = slices.Index(names, column)
+			}
+		} else if index >= 0 && index < len(record) {
+			fieldLine, _ := reader.FieldPos(index)
+			value, err := m.mapValue(record[index], fieldLine)
+			if err != nil {
+				return err
+			}
+			if value != record[index] {
+				out = chunk.replaceField(reader, record, index, value)
+			}
+		}
+		if index < 0 {
+			return fmt.Errorf("%w: %s", errUnknownColumn, column)
+		}
+		if _, err := writer.Write(out); err != nil {
+			return err //nolint:wrapcheck // write errors are reported as is
+		}
+	}
+	return writer.Flush() //nolint:wrapcheck // write errors are reported as is
+}
+
+// csvChunk is the input of a CSV record, with the blank lines before it and
+// its line ending.
+type csvChunk struct {
+	raw   []byte
+	line  int // line number of the first byte of raw
+	comma rune
+}
+
+// offset returns the index in raw of a field position reported by
+// csv.Reader.FieldPos.
+func (c csvChunk) offset(line, column int) int {
+	start := 0
+	for range line - c.line {
+		start += bytes.IndexByte(c.raw[start:], '\n') + 1
+	}
+	return start + column - 1
+}
+
+// replaceField returns raw with field index of record replaced by value,
+// quoted if the original field was or if value needs it.
+func (c csvChunk) replaceField(reader *csv.Reader, record []string, index int, value string) []byte {
+	start := c.offset(reader.FieldPos(index))
+	end := len(bytes.TrimSuffix(bytes.TrimSuffix(c.raw, []byte("\n")), []byte("\r")))
+	if index+1 < len(record) {
+		end = c.offset(reader.FieldPos(index+1)) - utf8.RuneLen(c.comma)
+	}
+
+	field := value
+	if (start < len(c.raw) && c.raw[start] == '"') || strings.ContainsRune(value, c.comma) || strings.ContainsAny(value, "\"\r\n") {
+		field = `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+	}
+	out := make([]byte, 0, len(c.raw)-(end-start)+len(field))
+	out = append(out, c.raw[:start]...)
+	out = append(out, field...)
+	return append(out, c.raw[end:]...)
+}
+
+// mapLines streams lines, mapping the first match of re, or its first group,
+// and copying everything else byte for byte.
+func (m *columnMapper) mapLines(stdin io.Reader, stdout io.Writer, re *regexp.Regexp) error {
+	reader := bufio.NewReader(stdin)
+	writer := bufio.NewWriter(stdout)
+	defer writer.Flush()
+
+	for n := 1; ; n++ {
+		line, readErr := reader.ReadString('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return failure("Failed to read input", readErr)
+		}
+		body := strings.TrimRight(line, "\r\n")
+		if bounds := re.FindStringSubmatchIndex(body); bounds != nil {
+			start, end := bounds[0], bounds[1]
+			if len(bounds) > 2 && bounds[2] >= 0 {
+				start, end = bounds[2], bounds[3]
+			}
+			value, err := m.mapValue(body[start:end], n)
+			if err != nil {
+				return err
+			}
+			line = body[:start] + value + body[end:] + line[len(body):]
+		}
+		if _, err := writer.WriteString(line); err != nil {
+			return err //nolint:wrapcheck // write errors are reported as is
+		}
+		if readErr != nil {
+			break
+		}
+	}
+	return writer.Flush() //nolint:wrapcheck // write errors are reported as is
+}
+
+// mapValue parses a date, applies the pipeline and formats the result.
+// Empty values are kept. With -keep-going, a failing value is reported and
+// kept unchanged.
+func (m *columnMapper) mapValue(value string, line int) (string, error) {
+	if strings.TrimSpace(value) == "" {
+		return value, nil
+	}
+	m.mapped++
+	mapped, err := m.transform(strings.TrimSpace(value))
+	if err == nil {
+		return mapped, nil
+	}
+	err = fmt.Errorf("line %d: %w", line, err)
+	if !m.r.config.keepGoing {
+		return "", err
+	}
+	m.failed++
+	fmt.Fprintln(m.stderr, err)
+	return value, nil
+}
+
+func (m *columnMapper) transform(value string) (string, error) {
+	ctx := m.r.newContext()
+	format := m.r.config.format
+	var t time.Time
+	var err error
+	if len(m.layouts) > 0 {
+		var i int
+		t, i, err = calcdate.ParseLayouts(value, m.layouts, m.r.tz)
+		if format == "" && err == nil {
+			format = m.layouts[i]
+		}
+	} else {
+		t, err = calcdate.ParseDateValue(value, ctx)
+	}
+	if err != nil {
+		return "", failure("Invalid date", err)
+	}
+	if t, err = calcdate.ApplyOperationsWithContext(t, m.operations, ctx); err != nil {
+		return "", failure("Failed to apply pipeline", err)
+	}
+	return m.r.formatTimeAs(t, format), nil
+}
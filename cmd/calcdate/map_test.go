@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/sgaunet/calcdate/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapCSVKeepsOriginalBytes(t *testing.T) {
+	input := "\ufeffid,\"created_at\",note\r\n" +
+		"1,\"2025-01-15 10:00:00\",\"a, b\"\r\n" +
+		"\r\n" +
+		"\"2\",2025-01-16 11:30:00,\"multi\r\nline\"\r\n" +
+		"\"x\r\ny\",2025-01-17 00:00:00,\"\"\"quoted\"\"\"\r\n" +
+		"3,,\r\n" +
+		"4"
+	expected := "\ufeffid,\"created_at\",note\r\n" +
+		"1,\"2025-01-16 10:00:00\",\"a, b\"\r\n" +
+		"\r\n" +
+		"\"2\",2025-01-17 11:30:00,\"multi\r\nline\"\r\n" +
+		"\"x\r\ny\",2025-01-18 00:00:00,\"\"\"quoted\"\"\"\r\n" +
+		"3,,\r\n" +
+		"4"
+
+	var out bytes.Buffer
+	r, err := newRunner(cliConfig{tz: "UTC", locale: "en"}, &out)
+	require.NoError(t, err)
+	operations, err := calcdate.ParsePipeline("| +1d")
+	require.NoError(t, err)
+	m := &columnMapper{r: r, operations: operations}
+
+	require.NoError(t, m.mapCSV(strings.NewReader(input), &out, ',', "created_at", true))
+	assert.Equal(t, expected, out.String())
+	assert.Equal(t, 3, m.mapped)
+}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sgaunet/calcdate/v2"
+)
+
+// errUnknownOutput is returned for an unsupported --output value.
+var errUnknownOutput = errors.New("unknown output format")
+
+// cellFormatter formats t for one output column. A nil location selects the
+// default output zone.
+type cellFormatter func(t time.Time, loc *time.Location) string
+
+// resultWriter renders single dates and range rows in one output format.
+type resultWriter interface {
+	writeDate(t time.Time) error
+	writeRange(begin, end time.Time) error
+	flush() error
+}
+
+// columns describes the output columns: one per --zones entry, or a single
+// unlabeled column in the default output zone.
+type columns struct {
+	zones  []*time.Location
+	now    time.Time
+	format cellFormatter
+}
+
+func (c columns) labeled() bool {
+	return len(c.zones) > 0
+}
+
+// cells formats t once per column.
+func (c columns) cells(t time.Time) []string {
+	if !c.labeled() {
+		return []string{c.format(t, nil)}
+	}
+	cells := make([]string, len(c.zones))
+	for i, loc := range c.zones {
+		cells[i] = c.format(t, loc)
+	}
+	return cells
+}
+
+// labels returns the column headers, e.g. "Europe/Paris (+02:00 CEST)".
+func (c columns) labels() []string {
+	labels := make([]string, len(c.zones))
+	for i, loc := range c.zones {
+		labels[i] = calcdate.ZoneLabel(c.now, loc)
+	}
+	return labels
+}
+
+//nolint:lll // long function signature is readable
+func newResultWriter(output string, w io.Writer, cols columns, ics icsSettings, sql sqlSettings) (resultWriter, error) {
+	switch output {
+	case "", "text":
+		return &textWriter{w: w, cols: cols}, nil
+	case "json":
+		return &jsonWriter{w: w, cols: cols}, nil
+	case "csv":
+		return &csvWriter{w: csv.NewWriter(w), cols: cols}, nil
+	case "ics":
+		return &icsWriter{w: w, settings: ics}, nil
+	case "sql-where":
+		if err := calcdate.ValidateSQLColumn(sql.column); err != nil {
+			return nil, err //nolint:wrapcheck // the error names the column
+		}
+		return &sqlWhereWriter{w: w, settings: sql}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownOutput, output)
+	}
+}
+
+// textWriter prints one line per result. With several zones, the columns are
+// aligned under a header line.
+type textWriter struct {
+	w      io.Writer
+	cols   columns
+	tw     *tabwriter.Writer
+	header bool
+}
+
+func (tw *textWriter) writeDate(t time.Time) error {
+	return tw.writeRow(tw.cols.cells(t))
+}
+
+func (tw *textWriter) writeRange(begin, end time.Time) error {
+	begins, ends := tw.cols.cells(begin), tw.cols.cells(end)
+	row := make([]string, len(begins))
+	for i := range begins {
+		row[i] = begins[i] + " - " + ends[i]
+	}
+	return tw.writeRow(row)
+}
+
+func (tw *textWriter) writeRow(row []string) error {
+	if !tw.cols.labeled() {
+		_, err := fmt.Fprintln(tw.w, row[0])
+		return err //nolint:wrapcheck // write errors are reported as is
+	}
+
+	const columnPadding = 3
+	if tw.tw == nil {
+		tw.tw = tabwriter.NewWriter(tw.w, 0, 0, columnPadding, ' ', 0)
+	}
+	if !tw.header {
+		tw.header = true
+		if _, err := fmt.Fprintln(tw.tw, strings.Join(tw.cols.labels(), "\t")); err != nil {
+			return err //nolint:wrapcheck // write errors are reported as is
+		}
+	}
+	_, err := fmt.Fprintln(tw.tw, strings.Join(row, "\t"))
+	return err //nolint:wrapcheck // write errors are reported as is
+}
+
+func (tw *textWriter) flush() error {
+	if tw.tw == nil {
+		return nil
+	}
+	return tw.tw.Flush() //nolint:wrapcheck // write errors are reported as is
+}
+
+// csvWriter prints a header row followed by one row per result.
+type csvWriter struct {
+	w      *csv.Writer
+	cols   columns
+	header bool
+}
+
+func (cw *csvWriter) writeDate(t time.Time) error {
+	if err := cw.writeHeader([]string{"date"}, []string{""}); err != nil {
+		return err
+	}
+	return cw.w.Write(cw.cols.cells(t)) //nolint:wrapcheck // write errors are reported as is
+}
+
+func (cw *csvWriter) writeRange(begin, end time.Time) error {
+	if err := cw.writeHeader([]string{"begin", "end"}, []string{" begin", " end"}); err != nil {
+		return err
+	}
+	begins, ends := cw.cols.cells(begin), cw.cols.cells(end)
+	row := make([]string, 0, 2*len(begins))
+	for i := range begins {
+		row = append(row, begins[i], ends[i])
+	}
+	return cw.w.Write(row) //nolint:wrapcheck // write errors are reported as is
+}
+
+// writeHeader writes the header row once: plain names for a single column,
+// or each zone label followed by the suffixes.
+func (cw *csvWriter) writeHeader(names, suffixes []string) error {
+	if cw.header {
+		return nil
+	}
+	cw.header = true
+	if !cw.cols.labeled() {
+		return cw.w.Write(names) //nolint:wrapcheck // write errors are reported as is
+	}
+	header := []string{}
+	for _, label := range cw.cols.labels() {
+		for _, suffix := range suffixes {
+			header = append(header, label+suffix)
+		}
+	}
+	return cw.w.Write(header) //nolint:wrapcheck // write errors are reported as is
+}
+
+func (cw *csvWriter) flush() error {
+	cw.w.Flush()
+	return cw.w.Error() //nolint:wrapcheck // write errors are reported as is
+}
+
+// jsonZone describes an output zone in the JSON document.
+type jsonZone struct {
+	Name         string `json:"name"`
+	Offset       string `json:"offset"`
+	Abbreviation string `json:"abbreviation"`
+}
+
+// jsonDocument is the JSON output: the zones (with --zones) and the results.
+// Each result has a "date" or "begin"/"end" keys whose values are strings,
+// or with --zones arrays of strings in the order of "zones".
+type jsonDocument struct {
+	Zones   []jsonZone       `json:"zones,omitempty"`
+	Results []map[string]any `json:"results"`
+}
+
+// jsonWriter collects the results and prints a single JSON document on flush.
+type jsonWriter struct {
+	w       io.Writer
+	cols    columns
+	results []map[string]any
+}
+
+func (jw *jsonWriter) writeDate(t time.Time) error {
+	jw.results = append(jw.results, map[string]any{"date": jw.value(t)})
+	return nil
+}
+
+func (jw *jsonWriter) writeRange(begin, end time.Time) error {
+	jw.results = append(jw.results, map[string]any{"begin": jw.value(begin), "end": jw.value(end)})
+	return nil
+}
+
+// value returns the formatted date, or the formatted dates in the order of
+// the zones: an array keeps repeated zones and the order they were given in.
+func (jw *jsonWriter) value(t time.Time) any {
+	cells := jw.cols.cells(t)
+	if !jw.cols.labeled() {
+		return cells[0]
+	}
+	return cells
+}
+
+func (jw *jsonWriter) flush() error {
+	doc := jsonDocument{Results: jw.results}
+	if doc.Results == nil {
+		doc.Results = []map[string]any{}
+	}
+	for _, loc := range jw.cols.zones {
+		now := jw.cols.now.In(loc)
+		doc.Zones = append(doc.Zones, jsonZone{
+			Name:         loc.String(),
+			Offset:       calcdate.FormatOffset(now),
+			Abbreviation: calcdate.ZoneAbbreviation(now, loc),
+		})
+	}
+
+	encoder := json.NewEncoder(jw.w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc) //nolint:wrapcheck // write errors are reported as is
+}
+
+// icsSettings configures the iCalendar output.
+type icsSettings struct {
+	// expr is the evaluated expression, from which event UIDs are derived.
+	expr string
+	// summary and description are templates expanded by icsWriter.expand.
+	summary, description string
+	// loc is the zone of the event times and of the VTIMEZONE.
+	loc    *time.Location
+	format cellFormatter
+	now    time.Time
+}
+
+// icsWriter collects one VEVENT per result and prints a VCALENDAR on flush.
+type icsWriter struct {
+	w        io.Writer
+	settings icsSettings
+	events   []calcdate.ICalEvent
+}
+
+func (iw *icsWriter) writeDate(t time.Time) error {
+	iw.add(t, time.Time{})
+	return nil
+}
+
+func (iw *icsWriter) writeRange(begin, end time.Time) error {
+	iw.add(begin, end)
+	return nil
+}
+
+func (iw *icsWriter) add(begin, end time.Time) {
+	summary := iw.settings.summary
+	if summary == "" {
+		summary = "$begin - $end"
+		if end.IsZero() {
+			summary = "$begin"
+		}
+	}
+	index := len(iw.events)
+	iw.events = append(iw.events, calcdate.ICalEvent{
+		UID:         calcdate.ICalUID(iw.settings.expr, index),
+		Start:       begin,
+		End:         end,
+		Summary:     iw.expand(summary, begin, end, index),
+		Description: iw.expand(iw.settings.description, begin, end, index),
+	})
+}
+
+// expand replaces $begin, $end (formatted like the other outputs), $index
+// (from 0) and $expr in a SUMMARY or DESCRIPTION template.
+func (iw *icsWriter) expand(template string, begin, end time.Time, index int) string {
+	if end.IsZero() {
+		end = begin
+	}
+	return strings.NewReplacer(
+		"$begin", iw.settings.format(begin, iw.settings.loc),
+		"$end", iw.settings.format(end, iw.settings.loc),
+		"$index", strconv.Itoa(index),
+		"$expr", iw.settings.expr,
+	).Replace(template)
+}
+
+func (iw *icsWriter) flush() error {
+	return calcdate.WriteICalendar(iw.w, iw.events, iw.settings.loc, iw.settings.now) //nolint:wrapcheck // write errors are reported as is
+}
+
+// sqlSettings configures the SQL predicate output.
+type sqlSettings struct {
+	column  string
+	dialect calcdate.SQLDialect
+	// loc is the zone of the literals.
+	loc *time.Location
+}
+
+// sqlWhereWriter prints one SQL predicate per result: a half-open
+// "column >= begin AND column < end" per range row, "column = date" per date.
+type sqlWhereWriter struct {
+	w        io.Writer
+	settings sqlSettings
+}
+
+func (sw *sqlWhereWriter) writeDate(t time.Time) error {
+	_, err := fmt.Fprintln(sw.w, calcdate.SQLDatePredicate(sw.settings.column, t.In(sw.settings.loc), sw.settings.dialect))
+	return err //nolint:wrapcheck // write errors are reported as is
+}
+
+func (sw *sqlWhereWriter) writeRange(begin, end time.Time) error {
+	loc := sw.settings.loc
+	_, err := fmt.Fprintln(sw.w, calcdate.SQLRangePredicate(sw.settings.column, begin.In(loc), end.In(loc), sw.settings.dialect))
+	return err //nolint:wrapcheck // write errors are reported as is
+}
+
+func (sw *sqlWhereWriter) flush() error {
+	return nil
+}
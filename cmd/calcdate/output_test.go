@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/sgaunet/calcdate/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultWriters(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	format := func(t time.Time, loc *time.Location) string {
+		if loc == nil {
+			loc = time.UTC
+		}
+		return t.In(loc).Format(time.DateTime)
+	}
+	single := columns{now: now, format: format}
+	zones := columns{zones: []*time.Location{paris, newYork}, now: now, format: format}
+
+	date := func(w resultWriter) error {
+		return w.writeDate(now)
+	}
+	rows := func(w resultWriter) error {
+		for day := range 2 {
+			begin := now.AddDate(0, 0, day)
+			if err := w.writeRange(begin, begin.Add(time.Hour)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	testCases := []struct {
+		name     string
+		output   string
+		cols     columns
+		write    func(w resultWriter) error
+		expected string
+	}{
+		{
+			name:     "text date",
+			output:   "text",
+			cols:     single,
+			write:    date,
+			expected: "2025-01-15 12:00:00\n",
+		},
+		{
+			name:   "text range",
+			output: "text",
+			cols:   single,
+			write:  rows,
+			expected: "2025-01-15 12:00:00 - 2025-01-15 13:00:00\n" +
+				"2025-01-16 12:00:00 - 2025-01-16 13:00:00\n",
+		},
+		{
+			name:   "text zones",
+			output: "text",
+			cols:   zones,
+			write:  date,
+			expected: "Europe/Paris (+01:00 CET)   America/New_York (-05:00 EST)\n" +
+				"2025-01-15 13:00:00         2025-01-15 07:00:00\n",
+		},
+		{
+			name:     "json date",
+			output:   "json",
+			cols:     single,
+			write:    date,
+			expected: "{\n  \"results\": [\n    {\n      \"date\": \"2025-01-15 12:00:00\"\n    }\n  ]\n}\n",
+		},
+		{
+			name:   "json range",
+			output: "json",
+			cols:   single,
+			write:  rows,
+			expected: "{\n  \"results\": [\n" +
+				"    {\n      \"begin\": \"2025-01-15 12:00:00\",\n      \"end\": \"2025-01-15 13:00:00\"\n    },\n" +
+				"    {\n      \"begin\": \"2025-01-16 12:00:00\",\n      \"end\": \"2025-01-16 13:00:00\"\n    }\n" +
+				"  ]\n}\n",
+		},
+		{
+			name:   "json zones",
+			output: "json",
+			cols:   zones,
+			write:  date,
+			expected: "{\n  \"zones\": [\n" +
+				"    {\n      \"name\": \"Europe/Paris\",\n      \"offset\": \"+01:00\",\n      \"abbreviation\": \"CET\"\n    },\n" +
+				"    {\n      \"name\": \"America/New_York\",\n      \"offset\": \"-05:00\",\n      \"abbreviation\": \"EST\"\n    }\n" +
+				"  ],\n  \"results\": [\n    {\n      \"date\": [\n" +
+				"        \"2025-01-15 13:00:00\",\n" +
+				"        \"2025-01-15 07:00:00\"\n" +
+				"      ]\n    }\n  ]\n}\n",
+		},
+		{
+			name:     "csv date",
+			output:   "csv",
+			cols:     single,
+			write:    date,
+			expected: "date\n2025-01-15 12:00:00\n",
+		},
+		{
+			name:   "csv range",
+			output: "csv",
+			cols:   single,
+			write:  rows,
+			expected: "begin,end\n" +
+				"2025-01-15 12:00:00,2025-01-15 13:00:00\n" +
+				"2025-01-16 12:00:00,2025-01-16 13:00:00\n",
+		},
+		{
+			name:   "csv zones",
+			output: "csv",
+			cols:   zones,
+			write:  rows,
+			expected: "Europe/Paris (+01:00 CET) begin,Europe/Paris (+01:00 CET) end," +
+				"America/New_York (-05:00 EST) begin,America/New_York (-05:00 EST) end\n" +
+				"2025-01-15 13:00:00,2025-01-15 14:00:00,2025-01-15 07:00:00,2025-01-15 08:00:00\n" +
+				"2025-01-16 13:00:00,2025-01-16 14:00:00,2025-01-16 07:00:00,2025-01-16 08:00:00\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			w, err := newResultWriter(tc.output, &out, tc.cols, icsSettings{}, sqlSettings{})
+			require.NoError(t, err)
+			require.NoError(t, tc.write(w))
+			require.NoError(t, w.flush())
+			assert.Equal(t, tc.expected, out.String())
+		})
+	}
+}
+
+func TestSQLWhereWriterRejectsColumn(t *testing.T) {
+	_, err := newResultWriter("sql-where", &bytes.Buffer{}, columns{}, icsSettings{},
+		sqlSettings{column: "ts; DROP TABLE orders", loc: time.UTC})
+	require.ErrorIs(t, err, calcdate.ErrInvalidSQLColumn)
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, assert.AnError
+}
+
+func TestCSVWriterReportsWriteErrors(t *testing.T) {
+	w, err := newResultWriter("csv", failingWriter{}, columns{format: func(t time.Time, _ *time.Location) string {
+		return t.Format(time.DateOnly)
+	}}, icsSettings{}, sqlSettings{})
+	require.NoError(t, err)
+	require.NoError(t, w.writeDate(time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)))
+	require.ErrorIs(t, w.flush(), assert.AnError)
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sgaunet/calcdate/v2"
+)
+
+// errPartitionsUsage is returned when "calcdate partitions" misses its pattern or range.
+var errPartitionsUsage = errors.New(
+	"usage: calcdate partitions --pattern=PATTERN [--each=1M] [--table=TABLE] [--drop-before=EXPR] 'RANGE'")
+
+// runPartitions implements "calcdate partitions": it splits a range into
+// partitions named from a pattern, and prints them with their bounds or, with
+// --table, the DDL creating them. --drop-before keeps the partitions ending
+// before a retention cutoff, with the DDL dropping them.
+//
+//	calcdate partitions --pattern=events_%Y_%m "2025-03-01...2025-06-01"
+//	calcdate partitions --pattern=events_%Y_%m --table=events --sql-dialect=mysql "2025-03-01...2025-06-01"
+//	calcdate partitions --pattern=events_%Y_%m_%d --each=1d --drop-before="today -30d" "today -1Y...today"
+func runPartitions(args []string, stdout io.Writer) error {
+	var config cliConfig
+	var pattern, table, dropBefore string
+
+	flags := flag.NewFlagSet("partitions", flag.ContinueOnError)
+	flags.StringVar(&config.tz, "tz", "Local", "Timezone of the partition bounds")
+	flags.StringVar(&config.expr, "expr", "", "Range to partition (e.g., '2025-03-01...2025-06-01')")
+	flags.StringVar(&config.expr, "x", "", "Range to partition (short form)")
+	flags.StringVar(&config.each, "each", "1M", "Partition interval (e.g., '1d', '1w', '1M')")
+	flags.StringVar(&pattern, "pattern", "", "Partition name with Unix date directives, formatted at its start (e.g., 'events_%Y_%m')")
+	flags.StringVar(&table, "table", "", "Partitioned table: print the DDL instead of the partition list")
+	flags.StringVar(&config.sqlDialect, "sql-dialect", "postgres",
+		"DDL dialect: postgres, or mysql for tables partitioned BY RANGE COLUMNS on a DATETIME")
+	flags.StringVar(&dropBefore, "drop-before", "",
+		"Retention cutoff: list the partitions ending before it, to drop (e.g., 'today -90d')")
+	flags.StringVar(&config.format, "format", "", "Output format of the bounds (same values as calcdate -format)")
+	flags.StringVar(&config.format, "f", "", "Output format (short form)")
+	if err := flags.Parse(args); err != nil {
+		return err //nolint:wrapcheck // flag errors are already printed with the usage
+	}
+	if config.expr == "" {
+		config.expr = strings.Join(flags.Args(), " ")
+	}
+	if pattern == "" || config.expr == "" {
+		return errPartitionsUsage
+	}
+	config.locale = "en"
+
+	r, err := newRunner(config, stdout)
+	if err != nil {
+		return err
+	}
+	dialect, err := calcdate.ParseSQLDialect(config.sqlDialect)
+	if err != nil {
+		return failure("Invalid SQL dialect", err)
+	}
+
+	partitions, err := r.partitions(pattern)
+	if err != nil {
+		return err
+	}
+	drop := dropBefore != ""
+	if drop {
+		cutoff, err := r.evaluateDate(dropBefore)
+		if err != nil {
+			return err
+		}
+		partitions = calcdate.PartitionsToDrop(partitions, cutoff)
+	}
+
+	if table == "" {
+		return r.writePartitions(partitions)
+	}
+	var ddl string
+	if drop {
+		ddl, err = calcdate.DropPartitionsDDL(table, partitions, dialect)
+	} else {
+		ddl, err = calcdate.CreatePartitionsDDL(table, partitions, dialect)
+	}
+	if err != nil {
+		return failure("Failed to generate DDL", err)
+	}
+	_, err = io.WriteString(r.stdout, ddl)
+	return err //nolint:wrapcheck // write errors are reported as is
+}
+
+// partitions splits the range expression into --each intervals named from pattern.
+func (r *runner) partitions(pattern string) ([]calcdate.Partition, error) {
+	rows, err := r.rangeRows(errPartitionsUsage)
+	if err != nil {
+		return nil, err
+	}
+	partitions, err := calcdate.NewPartitions(rows, pattern, r.formatter)
+	if err != nil {
+		return nil, failure("Invalid partition pattern", err)
+	}
+	return partitions, nil
+}
+
+// rangeRows splits the range expression into --each intervals in --tz. A
+// pipeline after the range applies to its end; any other expression is a
+// usage error.
+func (r *runner) rangeRows(usage error) ([]calcdate.IterationResult, error) {
+	node, err := calcdate.NewExprParser("").Parse(r.config.expr)
+	if err != nil {
+		return nil, failure("Failed to parse expression", err)
+	}
+	var start, end time.Time
+	switch n := node.(type) {
+	case *calcdate.RangeNode:
+		start, end, err = r.evaluateRange(n)
+	case *calcdate.PipeNode:
+		rangeNode, ok := n.Base.(*calcdate.RangeNode)
+		if !ok {
+			return nil, failure("Failed to parse expression", usage)
+		}
+		if start, end, err = r.evaluateRange(rangeNode); err == nil {
+			end, err = calcdate.ApplyOperationsWithContext(end, n.Operations, r.newContext())
+		}
+	default:
+		return nil, failure("Failed to parse expression", usage)
+	}
+	if err != nil {
+		return nil, failure("Failed to evaluate range", err)
+	}
+
+	rows, err := r.iterate(start.In(r.tz), end.In(r.tz), nil)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		rows[i].BeginTime, rows[i].EndTime = rows[i].BeginTime.In(r.tz), rows[i].EndTime.In(r.tz)
+	}
+	return rows, nil
+}
+
+// evaluateDate evaluates a single date expression such as "today -90d".
+func (r *runner) evaluateDate(expr string) (time.Time, error) {
+	node, err := calcdate.NewExprParser(expr).Parse(expr)
+	if err != nil {
+		return time.Time{}, failure("Failed to parse expression", err)
+	}
+	t, err := node.Evaluate(r.newContext())
+	if err != nil {
+		return time.Time{}, failure("Failed to evaluate expression", err)
+	}
+	return t, nil
+}
+
+// writePartitions prints one partition per line: its name, start and end.
+func (r *runner) writePartitions(partitions []calcdate.Partition) error {
+	const columnPadding = 2
+	tw := tabwriter.NewWriter(r.stdout, 0, 0, columnPadding, ' ', 0)
+	for _, p := range partitions {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", p.Name, r.formatTime(p.From), r.formatTime(p.To))
+	}
+	return tw.Flush() //nolint:wrapcheck // write errors are reported as is
+}
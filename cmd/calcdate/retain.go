@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sgaunet/calcdate/v2"
+)
+
+// runRetain implements "calcdate retain": it reads snapshot timestamps on
+// stdin, one per line, and prints whether each is kept or deleted by a
+// grandfather-father-son policy, with the reasons.
+//
+//	ls backups | sed 's/^db-//; s/\.dump$//' | calcdate retain --keep-daily 7 --keep-weekly 4 --keep-monthly 12
+//	calcdate retain --now "2025-03-01 00:00:00" --keep-hourly 24 -o json < snapshots.txt
+func runRetain(args []string, stdout io.Writer) error {
+	var config cliConfig
+	var policy calcdate.RetentionPolicy
+	var now string
+
+	flags := flag.NewFlagSet("retain", flag.ContinueOnError)
+	flags.StringVar(&config.tz, "tz", "Local", "Timezone of the periods and of snapshots without an offset")
+	flags.StringVar(&now, "now", "now", "Expression the periods are counted back from")
+	flags.IntVar(&policy.Hourly, "keep-hourly", 0, "Keep the newest snapshot of each of the last N hours")
+	flags.IntVar(&policy.Daily, "keep-daily", 0, "Keep the newest snapshot of each of the last N days")
+	flags.IntVar(&policy.Weekly, "keep-weekly", 0, "Keep the newest snapshot of each of the last N weeks (see -week-start)")
+	flags.IntVar(&policy.Monthly, "keep-monthly", 0, "Keep the newest snapshot of each of the last N months")
+	flags.IntVar(&policy.Yearly, "keep-yearly", 0, "Keep the newest snapshot of each of the last N years")
+	flags.StringVar(&config.weekStart, "week-start", "monday", "First day of the week of -keep-weekly")
+	flags.StringVar(&config.output, "output", "text", "Output type: text (tab-separated), json")
+	flags.StringVar(&config.output, "o", "text", "Output type (short form)")
+	if err := flags.Parse(args); err != nil {
+		return err //nolint:wrapcheck // flag errors are already printed with the usage
+	}
+	if err := policy.Validate(); err != nil {
+		return failure("Invalid policy", err)
+	}
+	config.locale = "en"
+
+	r, err := newRunner(config, stdout)
+	if err != nil {
+		return err
+	}
+	anchor, err := r.evaluateDate(now)
+	if err != nil {
+		return err
+	}
+
+	lines, snapshots, err := r.readSnapshots(os.Stdin)
+	if err != nil {
+		return err
+	}
+	decisions := calcdate.PlanRetention(snapshots, policy, anchor.In(r.tz), r.week)
+	if config.output == "json" {
+		return r.writeRetentionJSON(lines, decisions)
+	}
+	return r.writeRetention(lines, decisions)
+}
+
+// readSnapshots reads one timestamp per non-empty line.
+func (r *runner) readSnapshots(stdin io.Reader) ([]string, []time.Time, error) {
+	lines := []string{}
+	snapshots := []time.Time{}
+	scanner := bufio.NewScanner(stdin)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		t, err := calcdate.ParseDateValue(line, r.newContext())
+		if err != nil {
+			return nil, nil, failure(fmt.Sprintf("Invalid snapshot on line %d", n), err)
+		}
+		lines = append(lines, line)
+		snapshots = append(snapshots, t)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, failure("Failed to read snapshots", err)
+	}
+	return lines, snapshots, nil
+}
+
+// writeRetention prints "keep" or "delete", the snapshot as given and the
+// reasons, separated by tabs.
+func (r *runner) writeRetention(lines []string, decisions []calcdate.RetentionDecision) error {
+	for i, decision := range decisions {
+		action, reasons := "delete", "no rule"
+		if decision.Keep {
+			action, reasons = "keep", strings.Join(decision.Reasons, ", ")
+		}
+		if _, err := fmt.Fprintf(r.stdout, "%s\t%s\t%s\n", action, lines[i], reasons); err != nil {
+			return err //nolint:wrapcheck // write errors are reported as is
+		}
+	}
+	return nil
+}
+
+// retentionRecord is a snapshot decision as printed in JSON.
+type retentionRecord struct {
+	Snapshot string   `json:"snapshot"`
+	Time     string   `json:"time"`
+	Reasons  []string `json:"reasons,omitempty"`
+}
+
+func (r *runner) writeRetentionJSON(lines []string, decisions []calcdate.RetentionDecision) error {
+	doc := struct {
+		Keep   []retentionRecord `json:"keep"`
+		Delete []retentionRecord `json:"delete"`
+	}{Keep: []retentionRecord{}, Delete: []retentionRecord{}}
+	for i, decision := range decisions {
+		record := retentionRecord{Snapshot: lines[i], Time: r.formatOutput(decision.Time), Reasons: decision.Reasons}
+		if decision.Keep {
+			doc.Keep = append(doc.Keep, record)
+		} else {
+			doc.Delete = append(doc.Delete, record)
+		}
+	}
+	encoder := json.NewEncoder(r.stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc) //nolint:wrapcheck // write errors are reported as is
+}
@@ -0,0 +1,376 @@
+package calcdate
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchYears bounds the search for the next or previous fire time, so
+// schedules that never fire ("0 0 30 2 *") fail instead of looping.
+const cronSearchYears = 28
+
+// cronMacros maps the @-macros to their five-field schedule.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronField is the set of values allowed in a schedule field, as a bitmask.
+type cronField uint64
+
+func (f cronField) has(v int) bool {
+	return f&(1<<uint(v)) != 0
+}
+
+// cronFieldSpec describes one field of a schedule: its name, bounds and
+// value names.
+type cronFieldSpec struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var (
+	cronSecond = cronFieldSpec{name: "second", min: 0, max: 59}
+	cronMinute = cronFieldSpec{name: "minute", min: 0, max: 59}
+	cronHour   = cronFieldSpec{name: "hour", min: 0, max: 23}
+	cronDay    = cronFieldSpec{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronFieldSpec{name: "month", min: 1, max: 12, names: []string{
+		"", "jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec",
+	}}
+	cronWeekday = cronFieldSpec{name: "day of week", min: 0, max: 7, names: []string{
+		"sun", "mon", "tue", "wed", "thu", "fri", "sat",
+	}}
+)
+
+// CronSchedule is a parsed cron expression. Fire times are wall clocks in
+// the location of the time passed to Next or Prev: wall clocks skipped by a
+// DST gap do not fire, and wall clocks repeated by an overlap fire once.
+type CronSchedule struct {
+	// Spec is the expression as given, e.g. "0 9 * * 1-5" or "@daily".
+	Spec string
+	// Fields are the six fields (second to day of week) of the schedule, with
+	// "0" as the second of five-field expressions and macros expanded.
+	Fields [6]string
+
+	second, minute, hour, day, month, weekday cronField
+	// dayStar and weekdayStar record day fields starting with "*" (or "?"),
+	// "*/2" included as in Vixie cron: when neither does, a day matching
+	// either field fires.
+	dayStar, weekdayStar bool
+}
+
+// ParseCron parses a cron expression: five fields (minute hour day-of-month
+// month day-of-week), six fields with a leading second, or a macro such as
+// "@daily". Fields accept "*", "?", lists, ranges, steps ("*/15", "1-5/2")
+// and month and weekday names; 0 and 7 are Sunday.
+func ParseCron(spec string) (*CronSchedule, error) {
+	expanded := strings.TrimSpace(spec)
+	if strings.HasPrefix(expanded, "@") {
+		macro, ok := cronMacros[strings.ToLower(expanded)]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown macro %s", ErrInvalidCron, expanded)
+		}
+		expanded = macro
+	}
+
+	fields := strings.Fields(expanded)
+	const fiveFields, sixFields = 5, 6
+	switch len(fields) {
+	case fiveFields:
+		fields = append([]string{"0"}, fields...)
+	case sixFields:
+	default:
+		return nil, fmt.Errorf("%w: expected 5 or 6 fields, got %d in %q", ErrInvalidCron, len(fields), spec)
+	}
+
+	s := &CronSchedule{Spec: strings.TrimSpace(spec)}
+	copy(s.Fields[:], fields)
+	specs := []cronFieldSpec{cronSecond, cronMinute, cronHour, cronDay, cronMonth, cronWeekday}
+	targets := []*cronField{&s.second, &s.minute, &s.hour, &s.day, &s.month, &s.weekday}
+	for i, field := range fields {
+		set, err := parseCronField(field, specs[i])
+		if err != nil {
+			return nil, err
+		}
+		*targets[i] = set
+	}
+	if s.weekday.has(cronWeekday.max) {
+		s.weekday |= 1 << uint(time.Sunday)
+	}
+	s.dayStar = isCronDayStar(fields[3])
+	s.weekdayStar = isCronDayStar(fields[5])
+	return s, nil
+}
+
+func isCronWildcard(field string) bool {
+	return field == "*" || field == "?"
+}
+
+// isCronDayStar reports whether a day field counts as unrestricted for the
+// day-of-month or day-of-week rule: like Vixie cron, any field starting with "*".
+func isCronDayStar(field string) bool {
+	return field == "?" || strings.HasPrefix(field, "*")
+}
+
+// parseCronField parses a comma-separated list of values, ranges and steps.
+func parseCronField(field string, spec cronFieldSpec) (cronField, error) {
+	var set cronField
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("%w: invalid step in %s field %q", ErrInvalidCron, spec.name, part)
+			}
+			rangePart, step = part[:i], n
+		}
+
+		low, high, err := parseCronRange(rangePart, spec)
+		if err != nil {
+			return 0, err
+		}
+		if step > 1 && !strings.Contains(rangePart, "-") && !isCronWildcard(rangePart) {
+			high = spec.max
+		}
+		for v := low; v <= high; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+// parseCronRange parses "*", a single value or "low-high".
+func parseCronRange(part string, spec cronFieldSpec) (int, int, error) {
+	if isCronWildcard(part) {
+		return spec.min, spec.max, nil
+	}
+	lowText, highText, isRange := strings.Cut(part, "-")
+	low, err := parseCronValue(lowText, spec)
+	if err != nil {
+		return 0, 0, err
+	}
+	high := low
+	if isRange {
+		high, err = parseCronValue(highText, spec)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	if high < low {
+		return 0, 0, fmt.Errorf("%w: %s field range %q is reversed", ErrInvalidCron, spec.name, part)
+	}
+	return low, high, nil
+}
+
+func parseCronValue(text string, spec cronFieldSpec) (int, error) {
+	for i, name := range spec.names {
+		if name != "" && strings.EqualFold(text, name) {
+			return i, nil
+		}
+	}
+	v, err := strconv.Atoi(text)
+	if err != nil || v < spec.min || v > spec.max {
+		return 0, fmt.Errorf("%w: %s field value %q (expected %d-%d)", ErrInvalidCron, spec.name, text, spec.min, spec.max)
+	}
+	return v, nil
+}
+
+// matchesDay reports whether the schedule fires on the date of t.
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	if !s.month.has(int(t.Month())) {
+		return false
+	}
+	day, weekday := s.day.has(t.Day()), s.weekday.has(int(t.Weekday()))
+	if !s.dayStar && !s.weekdayStar {
+		return day || weekday
+	}
+	return day && weekday
+}
+
+// Next returns the first fire time strictly after t, in t's location.
+func (s *CronSchedule) Next(t time.Time) (time.Time, error) {
+	return s.search(t, 1)
+}
+
+// Prev returns the last fire time strictly before t, in t's location.
+func (s *CronSchedule) Prev(t time.Time) (time.Time, error) {
+	return s.search(t, -1)
+}
+
+// search walks the days from the date of t in the direction of step and
+// returns the first fire time strictly after (or before) t.
+func (s *CronSchedule) search(t time.Time, step int) (time.Time, error) {
+	loc := t.Location()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	limit := day.AddDate(step*cronSearchYears, 0, 0)
+	for ; !day.Equal(limit); day = day.AddDate(0, 0, step) {
+		if !s.matchesDay(day) {
+			continue
+		}
+		if fire, ok := s.searchDay(day, t, step, loc); ok {
+			return fire, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: %s within %d years of %s",
+		ErrCronNoMatch, s.Spec, cronSearchYears, t.Format(time.DateTime))
+}
+
+// searchDay returns the first (step 1) or last (step -1) fire time of day
+// strictly after (or before) t.
+func (s *CronSchedule) searchDay(day, t time.Time, step int, loc *time.Location) (time.Time, bool) {
+	sameDay := day.Year() == t.Year() && day.YearDay() == t.YearDay()
+	wall := t.Hour()*MinutesInHour*SecondsInMinute + t.Minute()*SecondsInMinute + t.Second()
+	minutes, seconds := s.minute.values(cronMinute, step), s.second.values(cronSecond, step)
+	for _, h := range s.hour.values(cronHour, step) {
+		for _, m := range minutes {
+			for _, sec := range seconds {
+				clock := h*MinutesInHour*SecondsInMinute + m*SecondsInMinute + sec
+				if sameDay && (clock-wall)*step < 0 {
+					continue
+				}
+				fire := time.Date(day.Year(), day.Month(), day.Day(), h, m, sec, 0, loc)
+				if fire.Hour() != h || fire.Minute() != m {
+					continue // skipped by a DST gap
+				}
+				if (step > 0 && fire.After(t)) || (step < 0 && fire.Before(t)) {
+					return fire, true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// values returns the values of the field in ascending order, or descending
+// when step is negative.
+func (f cronField) values(spec cronFieldSpec, step int) []int {
+	values := []int{}
+	for v := spec.min; v <= spec.max; v++ {
+		if f.has(v) {
+			values = append(values, v)
+		}
+	}
+	if step < 0 {
+		slices.Reverse(values)
+	}
+	return values
+}
+
+func isCronOperation(op string) bool {
+	return op == "nextcron" || op == "prevcron"
+}
+
+// applyCronOperation moves the date to the next or previous fire time of a
+// schedule, evaluated in the date's location.
+func applyCronOperation(date time.Time, op, value string) (operationResult, bool) {
+	if !isCronOperation(op) {
+		return operationResult{}, false
+	}
+	schedule, err := ParseCron(value)
+	if err != nil {
+		return operationResult{time.Time{}, err}, true
+	}
+	if op == "prevcron" {
+		t, err := schedule.Prev(date)
+		return operationResult{t, err}, true
+	}
+	t, err := schedule.Next(date)
+	return operationResult{t, err}, true
+}
+
+// IterateCron returns the fire times of schedule between start and end
+// (inclusive), evaluated in start's location, as rows whose begin and end
+// are the fire time. A transform ("$begin, $begin +1h") reshapes each row.
+//
+//nolint:lll // long function signature is readable
+func IterateCron(start, end time.Time, schedule *CronSchedule, transform *TransformNode, ctx *EvalContext) ([]IterationResult, error) {
+	fires := []time.Time{}
+	fire, err := schedule.Next(start.Add(-time.Nanosecond))
+	for err == nil && !fire.After(end) {
+		fires = append(fires, fire)
+		if len(fires) > MaxIterations {
+			return nil, ErrTooManyIterations
+		}
+		fire, err = schedule.Next(fire)
+	}
+	if err != nil && !errors.Is(err, ErrCronNoMatch) {
+		return nil, err
+	}
+	return occurrenceRows(fires, transform, ctx)
+}
+
+// CronFieldNames are the names of the six fields of a schedule, seconds first.
+var CronFieldNames = [6]string{"second", "minute", "hour", "day of month", "month", "day of week"}
+
+// Describe returns a readable description of the six fields of the schedule,
+// seconds first, e.g. "every 15 minutes", "9" or "Mon-Fri".
+func (s *CronSchedule) Describe() [6]string {
+	units := [6]string{"second", "minute", "hour", "day", "month", "day"}
+	specs := []cronFieldSpec{cronSecond, cronMinute, cronHour, cronDay, cronMonth, cronWeekday}
+	fields := []cronField{s.second, s.minute, s.hour, s.day, s.month, s.weekday}
+
+	var descriptions [6]string
+	for i, spec := range specs {
+		if spec.max == cronWeekday.max {
+			spec.max = int(time.Saturday)
+		}
+		descriptions[i] = describeCronField(fields[i].values(spec, 1), spec, units[i])
+	}
+	return descriptions
+}
+
+func describeCronField(values []int, spec cronFieldSpec, unit string) string {
+	if len(values) == spec.max-spec.min+1 {
+		return "every " + unit
+	}
+	if len(values) > 2 && values[0] == spec.min {
+		step := values[1] - values[0]
+		isStep := true
+		for i := 1; i < len(values); i++ {
+			isStep = isStep && values[i]-values[i-1] == step
+		}
+		if isStep && values[len(values)-1]+step > spec.max {
+			return fmt.Sprintf("every %d %ss", step, unit)
+		}
+	}
+
+	name := func(v int) string {
+		if v < len(spec.names) {
+			return strings.ToUpper(spec.names[v][:1]) + spec.names[v][1:]
+		}
+		return strconv.Itoa(v)
+	}
+	parts := []string{}
+	for i := 0; i < len(values); {
+		j := i
+		for j+1 < len(values) && values[j+1] == values[j]+1 {
+			j++
+		}
+		switch {
+		case j-i >= 2:
+			parts = append(parts, name(values[i])+"-"+name(values[j]))
+		case j > i:
+			parts = append(parts, name(values[i]), name(values[j]))
+		default:
+			parts = append(parts, name(values[i]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
+
+// DaysEither reports whether neither day field starts with "*", in which
+// case the schedule fires on days matching either of them.
+func (s *CronSchedule) DaysEither() bool {
+	return !s.dayStar && !s.weekdayStar
+}
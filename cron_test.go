@@ -0,0 +1,134 @@
+package calcdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronNext(t *testing.T) {
+	friday := time.Date(2025, 6, 13, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		spec     string
+		from     time.Time
+		expected string
+	}{
+		{"0 9 * * 1-5", friday, "2025-06-16 09:00:00"},
+		{"0 9 * * mon-fri", friday.Add(-2 * time.Hour), "2025-06-13 09:00:00"},
+		{"*/15 * * * *", friday, "2025-06-13 10:15:00"},
+		{"30 */20 * * * *", friday, "2025-06-13 10:00:30"},
+		{"0 0 1,15 * *", friday, "2025-06-15 00:00:00"},
+		{"0 0 13 * 5", friday, "2025-06-20 00:00:00"},
+		// a day field starting with "*" is not a restriction: odd days that are Mondays
+		{"0 0 */2 * 1", friday, "2025-06-23 00:00:00"},
+		{"0 12 * * 7", friday, "2025-06-15 12:00:00"},
+		{"0 0 29 feb *", friday, "2028-02-29 00:00:00"},
+		{"@monthly", friday, "2025-07-01 00:00:00"},
+		{"@hourly", friday, "2025-06-13 11:00:00"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.spec, func(t *testing.T) {
+			schedule, err := ParseCron(tc.spec)
+			require.NoError(t, err)
+			next, err := schedule.Next(tc.from)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, next.Format(time.DateTime))
+		})
+	}
+}
+
+func TestCronPrev(t *testing.T) {
+	schedule, err := ParseCron("0 9 * * 1-5")
+	require.NoError(t, err)
+
+	prev, err := schedule.Prev(time.Date(2025, 6, 16, 9, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, "2025-06-13 09:00:00", prev.Format(time.DateTime))
+
+	never, err := ParseCron("0 0 30 2 *")
+	require.NoError(t, err)
+	_, err = never.Prev(time.Now())
+	require.ErrorIs(t, err, ErrCronNoMatch)
+}
+
+func TestCronDST(t *testing.T) {
+	paris := mustLoad(t, "Europe/Paris")
+	schedule, err := ParseCron("30 2 * * *")
+	require.NoError(t, err)
+
+	// 02:30 does not exist on 2025-03-30 in Paris
+	next, err := schedule.Next(time.Date(2025, 3, 29, 12, 0, 0, 0, paris))
+	require.NoError(t, err)
+	assert.Equal(t, "2025-03-31 02:30:00 +0200", next.Format("2006-01-02 15:04:05 -0700"))
+}
+
+func TestParseCronErrors(t *testing.T) {
+	for _, spec := range []string{"", "0 9 * *", "60 * * * *", "0 9 * * 8", "0 9 5-1 * *", "*/0 * * * *", "@often", "0 9 * foo *"} {
+		_, err := ParseCron(spec)
+		require.ErrorIs(t, err, ErrInvalidCron, spec)
+	}
+}
+
+func TestCronDescribe(t *testing.T) {
+	schedule, err := ParseCron("*/15 9-17 * jan,feb,dec 1-5")
+	require.NoError(t, err)
+	assert.Equal(t, [6]string{"0", "every 15 minutes", "9-17", "every day", "Jan,Feb,Dec", "Mon-Fri"}, schedule.Describe())
+	assert.False(t, schedule.DaysEither())
+}
+
+func TestCronOperations(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected string
+	}{
+		{`2025-06-13 10:00:00 | nextCron "0 9 * * 1-5"`, "2025-06-16 09:00:00"},
+		{`2025-06-13 10:00:00 | prevCron '0 9 * * 1-5'`, "2025-06-13 09:00:00"},
+		{`2025-06-13 10:00:00 | nextCron "@daily" | +1h`, "2025-06-14 01:00:00"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			node, err := NewExprParser("").Parse(tc.expr)
+			require.NoError(t, err)
+			result, err := node.Evaluate(&EvalContext{Timezone: time.UTC})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result.Format(time.DateTime))
+		})
+	}
+
+	node, err := NewExprParser("").Parse(`today | nextcron "0 9 * * 1-5"`)
+	require.NoError(t, err)
+	assert.Equal(t, `today | nextCron "0 9 * * 1-5"`, exprString(node))
+
+	_, err = NewExprParser("").Parse(`today | nextCron "0 9 * *"`)
+	require.ErrorIs(t, err, ErrInvalidCron)
+	_, err = NewExprParser("").Parse(`today | nextCron "0 9 * * *`)
+	require.ErrorIs(t, err, ErrUnexpectedCharacter)
+}
+
+func TestIterateCron(t *testing.T) {
+	start := time.Date(2025, 6, 13, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 6, 17, 9, 0, 0, 0, time.UTC)
+	schedule, err := ParseCron("0 9 * * 1-5")
+	require.NoError(t, err)
+
+	results, err := IterateCron(start, end, schedule, nil, &EvalContext{Timezone: time.UTC})
+	require.NoError(t, err)
+	fires := []string{}
+	for _, r := range results {
+		assert.Equal(t, r.BeginTime, r.EndTime)
+		fires = append(fires, r.BeginTime.Format(time.DateOnly))
+	}
+	assert.Equal(t, []string{"2025-06-13", "2025-06-16", "2025-06-17"}, fires)
+
+	transform, err := NewExprParser("").ParseTransform("$begin, $begin +30m")
+	require.NoError(t, err)
+	results, err = IterateCron(start, end, schedule, transform, &EvalContext{Timezone: time.UTC})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, "2025-06-16 09:30:00", results[1].EndTime.Format(time.DateTime))
+}
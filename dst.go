@@ -0,0 +1,264 @@
+package calcdate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LocalTimeStatus tells whether a wall clock exists in a location.
+type LocalTimeStatus int
+
+// Local time statuses.
+const (
+	// LocalTimeValid is a wall clock that occurs exactly once.
+	LocalTimeValid LocalTimeStatus = iota
+	// LocalTimeAmbiguous is a wall clock repeated when the clocks go back.
+	LocalTimeAmbiguous
+	// LocalTimeNonexistent is a wall clock skipped when the clocks go forward.
+	// time.Date silently moves it by the size of the gap.
+	LocalTimeNonexistent
+)
+
+// String returns "valid", "ambiguous" or "nonexistent".
+func (s LocalTimeStatus) String() string {
+	switch s {
+	case LocalTimeAmbiguous:
+		return "ambiguous"
+	case LocalTimeNonexistent:
+		return "nonexistent"
+	default:
+		return "valid"
+	}
+}
+
+// CheckLocalTime reports whether the wall clock of wall (its date and clock
+// fields, whatever its location) exists once, twice or not at all in loc.
+func CheckLocalTime(wall time.Time, loc *time.Location) LocalTimeStatus {
+	switch len(localInstants(wall, loc)) {
+	case 0:
+		return LocalTimeNonexistent
+	case 1:
+		return LocalTimeValid
+	default:
+		return LocalTimeAmbiguous
+	}
+}
+
+// localInstants returns the instants whose wall clock in loc is the wall clock of wall.
+func localInstants(wall time.Time, loc *time.Location) []time.Time {
+	asUTC := wallClockInUTC(wall)
+	probe := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(),
+		wall.Nanosecond(), loc)
+
+	// Transitions are far apart: the offsets a day around cover both sides
+	instants := []time.Time{}
+	for _, around := range []time.Duration{-HoursInDay * time.Hour, 0, HoursInDay * time.Hour} {
+		_, offset := probe.Add(around).Zone()
+		candidate := asUTC.Add(-time.Duration(offset) * time.Second).In(loc)
+		if sameWallClock(candidate, wall) && !containsInstant(instants, candidate) {
+			instants = append(instants, candidate)
+		}
+	}
+	return instants
+}
+
+// wallClockInUTC returns the wall clock of t in UTC, where no local time is
+// skipped or repeated.
+func wallClockInUTC(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+func sameWallClock(a, b time.Time) bool {
+	return wallClockInUTC(a).Equal(wallClockInUTC(b))
+}
+
+func containsInstant(instants []time.Time, t time.Time) bool {
+	for _, instant := range instants {
+		if instant.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionKind describes the effect of a transition on local times.
+type TransitionKind string
+
+// Transition kinds.
+const (
+	// TransitionGap skips local times (clocks go forward).
+	TransitionGap TransitionKind = "gap"
+	// TransitionOverlap repeats local times (clocks go back).
+	TransitionOverlap TransitionKind = "overlap"
+	// TransitionRename only changes the abbreviation.
+	TransitionRename TransitionKind = "rename"
+)
+
+// DSTTransition is a change of UTC offset or abbreviation of a location.
+type DSTTransition struct {
+	// At is the instant of the transition, in the location.
+	At           time.Time
+	OffsetBefore string
+	OffsetAfter  string
+	AbbrevBefore string
+	AbbrevAfter  string
+	Kind         TransitionKind
+	// WindowStart and WindowEnd bound the wall clocks that are skipped (gap)
+	// or repeated (overlap), e.g. 02:00:00 to 03:00:00. Only their date and
+	// clock fields are meaningful: they are expressed in UTC.
+	WindowStart time.Time
+	WindowEnd   time.Time
+}
+
+// String describes the transition, e.g.
+// "2025-03-30 03:00:00 CEST: +01:00 CET -> +02:00 CEST, 02:00:00-03:00:00 skipped".
+func (t DSTTransition) String() string {
+	var effect string
+	switch t.Kind {
+	case TransitionGap:
+		effect = fmt.Sprintf(", %s-%s skipped", t.WindowStart.Format(time.TimeOnly), t.WindowEnd.Format(time.TimeOnly))
+	case TransitionOverlap:
+		effect = fmt.Sprintf(", %s-%s repeated", t.WindowStart.Format(time.TimeOnly), t.WindowEnd.Format(time.TimeOnly))
+	case TransitionRename:
+		effect = ", abbreviation only"
+	}
+	return fmt.Sprintf("%s: %s %s -> %s %s%s", t.At.Format("2006-01-02 15:04:05 MST"),
+		t.OffsetBefore, t.AbbrevBefore, t.OffsetAfter, t.AbbrevAfter, effect)
+}
+
+// DSTTransitions lists the transitions of loc in [start, end).
+func DSTTransitions(loc *time.Location, start, end time.Time) ([]DSTTransition, error) {
+	if loc == nil {
+		return nil, fmt.Errorf("%w: missing location", ErrInvalidTimezone)
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("%w: end is before start", ErrInvalidRange)
+	}
+
+	transitions := []DSTTransition{}
+	current := start.In(loc)
+	for len(transitions) < MaxIterations {
+		_, next := current.ZoneBounds()
+		if next.IsZero() || !next.Before(end) {
+			break
+		}
+		transitions = append(transitions, newDSTTransition(next.In(loc)))
+		current = next
+	}
+	return transitions, nil
+}
+
+func newDSTTransition(at time.Time) DSTTransition {
+	before := at.Add(-time.Nanosecond)
+	abbrevBefore, offsetBefore := before.Zone()
+	abbrevAfter, offsetAfter := at.Zone()
+
+	transition := DSTTransition{
+		At:           at,
+		OffsetBefore: FormatOffset(before),
+		OffsetAfter:  FormatOffset(at),
+		AbbrevBefore: abbrevBefore,
+		AbbrevAfter:  abbrevAfter,
+	}
+
+	// Wall clocks of the instant with the old and the new offset
+	wallAfter := wallClockInUTC(at)
+	wallBefore := wallAfter.Add(time.Duration(offsetBefore-offsetAfter) * time.Second)
+	switch {
+	case offsetAfter > offsetBefore:
+		transition.Kind = TransitionGap
+		transition.WindowStart, transition.WindowEnd = wallBefore, wallAfter
+	case offsetAfter < offsetBefore:
+		transition.Kind = TransitionOverlap
+		transition.WindowStart, transition.WindowEnd = wallAfter, wallBefore
+	default:
+		transition.Kind = TransitionRename
+	}
+	return transition
+}
+
+// DSTPolicy selects the instant of a wall clock skipped (gap) or repeated
+// (overlap) by a transition. The zero value is DSTLater.
+type DSTPolicy string
+
+// DST policies.
+const (
+	// DSTEarlier takes the first occurrence of a repeated wall clock, and
+	// moves a skipped one back by the gap (02:30 -> 01:30 with the old offset).
+	DSTEarlier DSTPolicy = "earlier"
+	// DSTLater takes the second occurrence of a repeated wall clock, and moves
+	// a skipped one forward by the gap (02:30 -> 03:30). time.Date does not
+	// follow a policy: in New York, it takes the first occurrence of 01:30 on
+	// 2025-11-02 and moves 02:30 on 2025-03-09 back to 01:30.
+	DSTLater DSTPolicy = "later"
+	// DSTShiftForward moves a skipped wall clock to the end of the gap
+	// (02:30 -> 03:00) and takes the first occurrence of a repeated one.
+	DSTShiftForward DSTPolicy = "shift-forward"
+	// DSTError rejects skipped and repeated wall clocks.
+	DSTError DSTPolicy = "error"
+)
+
+// DSTPolicies lists the policies accepted by ParseDSTPolicy.
+var DSTPolicies = []DSTPolicy{DSTEarlier, DSTLater, DSTShiftForward, DSTError}
+
+// ParseDSTPolicy parses a policy name; "" is DSTLater.
+func ParseDSTPolicy(name string) (DSTPolicy, error) {
+	if name == "" {
+		return DSTLater, nil
+	}
+	for _, policy := range DSTPolicies {
+		if strings.EqualFold(name, string(policy)) {
+			return policy, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownDSTPolicy, name)
+}
+
+// ResolveLocalTime returns the instant of the wall clock of wall (its date
+// and clock fields, whatever its location) in loc, following policy when the
+// wall clock is skipped or repeated.
+func ResolveLocalTime(wall time.Time, loc *time.Location, policy DSTPolicy) (time.Time, error) {
+	instants := localInstants(wall, loc)
+	sort.Slice(instants, func(i, j int) bool { return instants[i].Before(instants[j]) })
+
+	switch len(instants) {
+	case 1:
+		return instants[0], nil
+	case 0:
+		return resolveGap(wall, loc, policy)
+	}
+
+	switch policy {
+	case DSTError:
+		return time.Time{}, fmt.Errorf("%w: %s in %s", ErrAmbiguousLocalTime, wall.Format(time.DateTime), loc)
+	case DSTEarlier, DSTShiftForward:
+		return instants[0], nil
+	default:
+		return instants[len(instants)-1], nil
+	}
+}
+
+// resolveGap resolves a wall clock skipped when the offset of loc went from
+// before to after.
+func resolveGap(wall time.Time, loc *time.Location, policy DSTPolicy) (time.Time, error) {
+	asUTC := wallClockInUTC(wall)
+	probe := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(),
+		wall.Nanosecond(), loc)
+	_, before := probe.Add(-HoursInDay * time.Hour).Zone()
+	_, after := probe.Add(HoursInDay * time.Hour).Zone()
+	later := asUTC.Add(-time.Duration(before) * time.Second).In(loc)
+
+	switch policy {
+	case DSTError:
+		return time.Time{}, fmt.Errorf("%w: %s in %s", ErrNonexistentLocalTime, wall.Format(time.DateTime), loc)
+	case DSTEarlier:
+		return asUTC.Add(-time.Duration(after) * time.Second).In(loc), nil
+	case DSTShiftForward:
+		transition, _ := later.ZoneBounds()
+		return transition, nil
+	default:
+		return later, nil
+	}
+}
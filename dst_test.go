@@ -0,0 +1,245 @@
+package calcdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDSTTransitions(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, paris)
+	transitions, err := DSTTransitions(paris, start, start.AddDate(1, 0, 0))
+	require.NoError(t, err)
+	require.Len(t, transitions, 2)
+
+	spring := transitions[0]
+	assert.Equal(t, time.Date(2025, 3, 30, 1, 0, 0, 0, time.UTC), spring.At.UTC())
+	assert.Equal(t, TransitionGap, spring.Kind)
+	assert.Equal(t, "+01:00", spring.OffsetBefore)
+	assert.Equal(t, "+02:00", spring.OffsetAfter)
+	assert.Equal(t, "CET", spring.AbbrevBefore)
+	assert.Equal(t, "CEST", spring.AbbrevAfter)
+	assert.Equal(t, "02:00:00", spring.WindowStart.Format(time.TimeOnly))
+	assert.Equal(t, "03:00:00", spring.WindowEnd.Format(time.TimeOnly))
+	assert.Equal(t, "2025-03-30 03:00:00 CEST: +01:00 CET -> +02:00 CEST, 02:00:00-03:00:00 skipped", spring.String())
+
+	autumn := transitions[1]
+	assert.Equal(t, time.Date(2025, 10, 26, 1, 0, 0, 0, time.UTC), autumn.At.UTC())
+	assert.Equal(t, TransitionOverlap, autumn.Kind)
+	assert.Equal(t, "02:00:00", autumn.WindowStart.Format(time.TimeOnly))
+	assert.Equal(t, "03:00:00", autumn.WindowEnd.Format(time.TimeOnly))
+
+	transitions, err = DSTTransitions(time.UTC, start, start.AddDate(1, 0, 0))
+	require.NoError(t, err)
+	assert.Empty(t, transitions)
+
+	_, err = DSTTransitions(paris, start, start.AddDate(-1, 0, 0))
+	require.ErrorIs(t, err, ErrInvalidRange)
+}
+
+func TestCheckLocalTime(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+
+	tests := []struct {
+		wall     time.Time
+		expected LocalTimeStatus
+	}{
+		{time.Date(2025, 3, 30, 2, 30, 0, 0, time.UTC), LocalTimeNonexistent},
+		{time.Date(2025, 3, 30, 3, 0, 0, 0, time.UTC), LocalTimeValid},
+		{time.Date(2025, 10, 26, 2, 30, 0, 0, time.UTC), LocalTimeAmbiguous},
+		{time.Date(2025, 10, 26, 3, 0, 0, 0, time.UTC), LocalTimeValid},
+		{time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC), LocalTimeValid},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.wall.Format(time.DateTime), func(t *testing.T) {
+			assert.Equal(t, tc.expected, CheckLocalTime(tc.wall, paris))
+		})
+	}
+}
+
+func TestEvaluateLocalTimeStatus(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+
+	tests := []struct {
+		expr     string
+		expected LocalTimeStatus
+	}{
+		{"2025-03-30 02:30:00", LocalTimeNonexistent},
+		{"2025-03-29 02:30:00 | +1d", LocalTimeNonexistent},
+		{"2025-03-30 | time 02:30", LocalTimeNonexistent},
+		{"2025-10-26 02:30:00", LocalTimeAmbiguous},
+		{"2025-03-30 01:30:00 | +1h", LocalTimeValid},
+		{"2025-06-01 12:00:00", LocalTimeValid},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			node, err := NewExprParser("").Parse(tc.expr)
+			require.NoError(t, err)
+			ctx := &EvalContext{Now: time.Date(2025, 1, 1, 0, 0, 0, 0, paris), Timezone: paris}
+			_, status, err := EvaluateLocalTime(node, ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, status)
+			assert.Equal(t, EvalContext{Now: ctx.Now, Timezone: paris}, *ctx, "the context is left unchanged")
+		})
+	}
+}
+
+func TestParsePredicate(t *testing.T) {
+	summer := time.Date(2025, 7, 1, 12, 0, 0, 0, mustLoad(t, "Europe/Paris"))
+
+	tests := []struct {
+		expr     string
+		status   LocalTimeStatus
+		expected bool
+	}{
+		{"isNonexistent", LocalTimeNonexistent, true},
+		{"isNonexistent", LocalTimeAmbiguous, false},
+		{"isAmbiguous or isNonexistent", LocalTimeAmbiguous, true},
+		{"not isValidLocal", LocalTimeValid, false},
+		{"isDST and isValidLocal", LocalTimeValid, true},
+		{"isDST and not isValidLocal or isAmbiguous", LocalTimeAmbiguous, true},
+		{"ISDST AND NOT ISVALIDLOCAL", LocalTimeValid, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			p, err := ParsePredicate(tc.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, p.Match(summer, tc.status, nil))
+		})
+	}
+
+	for _, expr := range []string{"", "isWhatever", "isDST and", "isDST isAmbiguous", "not"} {
+		_, err := ParsePredicate(expr)
+		require.ErrorIs(t, err, ErrInvalidPredicate, expr)
+	}
+}
+
+func TestResolveLocalTime(t *testing.T) {
+	paris := mustLoad(t, "Europe/Paris")
+	gap := time.Date(2025, 3, 30, 2, 30, 0, 0, time.UTC)
+	overlap := time.Date(2025, 10, 26, 2, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		policy   DSTPolicy
+		wall     time.Time
+		expected time.Time
+	}{
+		{DSTEarlier, gap, time.Date(2025, 3, 30, 0, 30, 0, 0, time.UTC)},
+		{DSTLater, gap, time.Date(2025, 3, 30, 1, 30, 0, 0, time.UTC)},
+		{DSTShiftForward, gap, time.Date(2025, 3, 30, 1, 0, 0, 0, time.UTC)},
+		{DSTEarlier, overlap, time.Date(2025, 10, 26, 0, 30, 0, 0, time.UTC)},
+		{DSTLater, overlap, time.Date(2025, 10, 26, 1, 30, 0, 0, time.UTC)},
+		{DSTShiftForward, overlap, time.Date(2025, 10, 26, 0, 30, 0, 0, time.UTC)},
+		{"", overlap, time.Date(2025, 10, 26, 1, 30, 0, 0, time.UTC)},
+		{DSTError, time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC), time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.policy)+" "+tc.wall.Format(time.DateTime), func(t *testing.T) {
+			result, err := ResolveLocalTime(tc.wall, paris, tc.policy)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result.UTC())
+			assert.Equal(t, paris, result.Location())
+		})
+	}
+
+	_, err := ResolveLocalTime(gap, paris, DSTError)
+	require.ErrorIs(t, err, ErrNonexistentLocalTime)
+	_, err = ResolveLocalTime(overlap, paris, DSTError)
+	require.ErrorIs(t, err, ErrAmbiguousLocalTime)
+}
+
+func TestDSTLaterDefaultInNewYork(t *testing.T) {
+	newYork := mustLoad(t, "America/New_York")
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+		timeDate time.Time
+	}{
+		// 01:30 is repeated: EST (the second occurrence), where time.Date takes EDT
+		{"2025-11-02 01:30:00", time.Date(2025, 11, 2, 6, 30, 0, 0, time.UTC),
+			time.Date(2025, 11, 2, 1, 30, 0, 0, newYork)},
+		// 02:30 is skipped: 03:30 EDT, where time.Date gives 01:30 EST
+		{"2025-03-09 02:30:00", time.Date(2025, 3, 9, 7, 30, 0, 0, time.UTC),
+			time.Date(2025, 3, 9, 2, 30, 0, 0, newYork)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			node, err := NewExprParser("").Parse(tc.expr)
+			require.NoError(t, err)
+			result, err := node.Evaluate(&EvalContext{Timezone: newYork})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result.UTC())
+			assert.NotEqual(t, tc.timeDate.UTC(), result.UTC())
+		})
+	}
+}
+
+func TestParseDSTPolicy(t *testing.T) {
+	for _, name := range []string{"earlier", "later", "shift-forward", "error", "Shift-Forward"} {
+		_, err := ParseDSTPolicy(name)
+		require.NoError(t, err, name)
+	}
+	policy, err := ParseDSTPolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, DSTLater, policy)
+
+	_, err = ParseDSTPolicy("nearest")
+	require.ErrorIs(t, err, ErrUnknownDSTPolicy)
+}
+
+func TestEvaluateWithDSTPolicy(t *testing.T) {
+	paris := mustLoad(t, "Europe/Paris")
+
+	tests := []struct {
+		expr     string
+		policy   DSTPolicy
+		expected string
+	}{
+		{"2025-03-30 | time 02:30", DSTEarlier, "2025-03-30 01:30:00 CET"},
+		{"2025-03-30 | time 02:30", DSTLater, "2025-03-30 03:30:00 CEST"},
+		{"2025-03-30 | time 02:30", DSTShiftForward, "2025-03-30 03:00:00 CEST"},
+		{"2025-03-29 02:30:00 | +1d", DSTShiftForward, "2025-03-30 03:00:00 CEST"},
+		{"2025-03-30 02:30:00", DSTEarlier, "2025-03-30 01:30:00 CET"},
+		{"2025-10-26 02:30:00", DSTEarlier, "2025-10-26 02:30:00 CEST"},
+		{"2025-10-26 02:45:00 | startOfHour", DSTEarlier, "2025-10-26 02:00:00 CEST"},
+		{"2025-10-26 02:45:00 | startOfHour", DSTLater, "2025-10-26 02:00:00 CET"},
+		{"2025-03-30 01:30:00 | +1h", DSTError, "2025-03-30 03:30:00 CEST"},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.policy)+" "+tc.expr, func(t *testing.T) {
+			node, err := NewExprParser("").Parse(tc.expr)
+			require.NoError(t, err)
+			result, err := node.Evaluate(&EvalContext{Timezone: paris, DSTPolicy: tc.policy})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result.Format("2006-01-02 15:04:05 MST"))
+		})
+	}
+
+	for _, expr := range []string{"2025-03-30 | time 02:30", "2025-10-26 02:30:00", "2025-03-29 02:30:00 | +1d"} {
+		node, err := NewExprParser("").Parse(expr)
+		require.NoError(t, err)
+		_, err = node.Evaluate(&EvalContext{Timezone: paris, DSTPolicy: DSTError})
+		require.Error(t, err, expr)
+	}
+}
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
@@ -53,6 +53,33 @@ var (
 	ErrInvalidHour                 = errors.New("invalid hour")
 	ErrInvalidMinute               = errors.New("invalid minute")
 	ErrInvalidSecond               = errors.New("invalid second")
+	ErrNotNaturalLanguage          = errors.New("not a natural-language phrase")
+	ErrUnknownLocale               = errors.New("unknown locale")
+	ErrAmbiguousTimezoneAbbrev     = errors.New("ambiguous timezone abbreviation")
+	ErrUnknownTZAbbrevPolicy       = errors.New("unknown timezone abbreviation policy")
+	ErrNoTimezoneDatabase          = errors.New("no timezone database available")
+	ErrInvalidOffset               = errors.New("invalid UTC offset")
+	ErrInvalidPredicate            = errors.New("invalid predicate")
+	ErrUnknownDSTPolicy            = errors.New("unknown DST policy")
+	ErrNonexistentLocalTime        = errors.New("nonexistent local time")
+	ErrAmbiguousLocalTime          = errors.New("ambiguous local time")
+	ErrUnknownMonthOverflowPolicy  = errors.New("unknown month overflow policy")
+	ErrMonthOverflow               = errors.New("day does not exist in the target month")
+	ErrUnknownRetailPattern        = errors.New("unknown retail calendar pattern")
+	ErrUnknownRetailYearEnd        = errors.New("unknown retail year-end rule")
+	ErrInvalidCron                 = errors.New("invalid cron expression")
+	ErrCronNoMatch                 = errors.New("cron schedule never fires")
+	ErrInvalidRRule                = errors.New("invalid recurrence rule")
+	ErrInvalidICalendar            = errors.New("invalid iCalendar file")
+	ErrUnknownCalendar             = errors.New("unknown calendar")
+	ErrNoEvent                     = errors.New("no calendar event found")
+	ErrUnknownSQLDialect           = errors.New("unknown SQL dialect")
+	ErrInvalidSQLColumn            = errors.New("invalid SQL column")
+	ErrInvalidPartitionPattern     = errors.New("invalid partition pattern")
+	ErrUnsupportedPartitionDialect = errors.New("partition DDL is not supported for this SQL dialect")
+	ErrInvalidRetentionPolicy      = errors.New("invalid retention policy")
+	ErrInvalidPipeline             = errors.New("invalid pipeline")
+	ErrNoMatchingLayout            = errors.New("value matches no input layout")
 )
 
 // Constants for magic numbers.
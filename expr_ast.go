@@ -1,6 +1,9 @@
 package calcdate
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -11,10 +14,24 @@ type ExprNode interface {
 
 // EvalContext provides context for expression evaluation.
 type EvalContext struct {
-	Now      time.Time
-	Timezone *time.Location
+	Now       time.Time
+	Timezone  *time.Location
 	Variables map[string]time.Time
-	Index    int
+	Index     int
+	// AbbrevPolicy resolves ambiguous abbreviations in literals like "10:00 IST".
+	AbbrevPolicy TZAbbrevPolicy
+	// DSTPolicy resolves wall clocks skipped or repeated by DST (DSTLater when empty).
+	DSTPolicy DSTPolicy
+	// MonthOverflow handles "2024-01-31 +1M" (MonthOverflow when empty).
+	MonthOverflow MonthOverflowPolicy
+	// Week sets the first day of the week and the weekend days (ISOWeek when nil).
+	Week *Week
+	// FiscalYearStart is the first month of the fiscal year (January when zero).
+	FiscalYearStart time.Month
+	// Retail is the 4-4-5 style calendar of startOfPeriod/endOfPeriod.
+	Retail RetailCalendar
+	// Calendars are the named iCalendar files of nextEvent/prevEvent and isInEvent.
+	Calendars map[string]*Calendar
 }
 
 // DateNode represents a date/time value.
@@ -49,4 +66,55 @@ type VariableNode struct {
 type TransformNode struct {
 	BeginExpr ExprNode
 	EndExpr   ExprNode
-}
\ No newline at end of file
+}
+
+// String returns the canonical expression for the date value.
+func (n *DateNode) String() string {
+	return n.Value
+}
+
+// String returns the canonical expression for the operation, e.g. "+1d" or "time 12:00".
+func (n *OperationNode) String() string {
+	switch {
+	case n.Op == "+" || n.Op == "-":
+		return n.Op + n.Value
+	case n.Value == "":
+		return canonicalKeyword(n.Op)
+	case isCronOperation(n.Op), isEventOperation(n.Op) && !isPlainCalendarName(n.Value):
+		return canonicalKeyword(n.Op) + " " + strconv.Quote(n.Value)
+	default:
+		return canonicalKeyword(n.Op) + " " + n.Value
+	}
+}
+
+// String returns the canonical expression for the pipeline, e.g. "now | -3d | time 12:00".
+func (n *PipeNode) String() string {
+	parts := []string{exprString(n.Base)}
+	for _, op := range n.Operations {
+		parts = append(parts, exprString(op))
+	}
+	return strings.Join(parts, " | ")
+}
+
+// String returns the canonical expression for the range.
+func (n *RangeNode) String() string {
+	return exprString(n.Start) + "..." + exprString(n.End)
+}
+
+// String returns the variable name.
+func (n *VariableNode) String() string {
+	return n.Name
+}
+
+// String returns the canonical transform expression.
+func (n *TransformNode) String() string {
+	return exprString(n.BeginExpr) + ", " + exprString(n.EndExpr)
+}
+
+// exprString returns the canonical expression of a node.
+func exprString(node ExprNode) string {
+	if s, ok := node.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprintf("%v", node)
+}
@@ -2,12 +2,50 @@ package calcdate
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
 // Evaluate evaluates a DateNode.
 func (n *DateNode) Evaluate(ctx *EvalContext) (time.Time, error) {
-	return ParseDateValue(n.Value, ctx)
+	t, _, err := n.evaluate(ctx)
+	return t, err
+}
+
+// evaluate also returns whether the wall clock of the value exists.
+func (n *DateNode) evaluate(ctx *EvalContext) (time.Time, LocalTimeStatus, error) {
+	t, err := ParseDateValue(n.Value, ctx)
+	if err != nil {
+		return time.Time{}, LocalTimeValid, err
+	}
+	wall, isWallClock := dateValueWall(n.Value, ctx)
+	if !isWallClock {
+		return t, CheckLocalTime(t, t.Location()), nil
+	}
+	status := CheckLocalTime(wall, t.Location())
+	if status == LocalTimeValid {
+		return t, status, nil
+	}
+	t, err = ResolveLocalTime(wall, t.Location(), ctx.DSTPolicy)
+	return t, status, err
+}
+
+// EvaluateLocalTime evaluates a date expression like its Evaluate method and
+// also returns whether the wall clock it asked for, by its date value or its
+// last operation, was valid, ambiguous, or skipped by a DST gap.
+func EvaluateLocalTime(node ExprNode, ctx *EvalContext) (time.Time, LocalTimeStatus, error) {
+	switch n := node.(type) {
+	case *DateNode:
+		return n.evaluate(ctx)
+	case *PipeNode:
+		return n.evaluate(ctx)
+	default:
+		t, err := node.Evaluate(ctx)
+		if err != nil {
+			return time.Time{}, LocalTimeValid, err
+		}
+		return t, CheckLocalTime(t, t.Location()), nil
+	}
 }
 
 // Evaluate evaluates an OperationNode.
@@ -18,28 +56,80 @@ func (n *OperationNode) Evaluate(_ *EvalContext) (time.Time, error) {
 
 // Evaluate evaluates a PipeNode.
 func (n *PipeNode) Evaluate(ctx *EvalContext) (time.Time, error) {
+	t, _, err := n.evaluate(ctx)
+	return t, err
+}
+
+// evaluate also returns whether the wall clock asked for by the last
+// operation, or by the base without operations, exists.
+func (n *PipeNode) evaluate(ctx *EvalContext) (time.Time, LocalTimeStatus, error) {
 	// Evaluate base
-	result, err := n.Base.Evaluate(ctx)
+	result, status, err := EvaluateLocalTime(n.Base, ctx)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("base evaluation failed: %w", err)
+		return time.Time{}, LocalTimeValid, fmt.Errorf("base evaluation failed: %w", err)
 	}
 	
-	// Apply each operation in sequence
-	for _, op := range n.Operations {
-		switch opNode := op.(type) {
-		case *OperationNode:
-			result, err = ApplyOperation(result, opNode.Op, opNode.Value, ctx.Timezone)
-			if err != nil {
-				return time.Time{}, err
-			}
-		default:
+	result, err = applyOperations(result, n.Operations, ctx.Timezone, ctx.operationOptions(), &status)
+	return result, status, err
+}
+
+// ApplyOperations applies pipeline operations in sequence. After a "tz" or
+// "utc" operation, the following operations work in the new location.
+func ApplyOperations(date time.Time, operations []ExprNode, loc *time.Location) (time.Time, error) {
+	var status LocalTimeStatus
+	return applyOperations(date, operations, loc, operationOptions{}, &status)
+}
+
+// ApplyOperationsWithContext is ApplyOperations in ctx.Timezone, following
+// the DST and month overflow policies of ctx.
+func ApplyOperationsWithContext(date time.Time, operations []ExprNode, ctx *EvalContext) (time.Time, error) {
+	var status LocalTimeStatus
+	return applyOperations(date, operations, ctx.Timezone, ctx.operationOptions(), &status)
+}
+
+// applyOperations applies the operations and records in status whether the
+// wall clock asked for by the last operation exists.
+func applyOperations(
+	date time.Time, operations []ExprNode, loc *time.Location, opts operationOptions, status *LocalTimeStatus,
+) (time.Time, error) {
+	result := date
+	for _, op := range operations {
+		opNode, ok := op.(*OperationNode)
+		if !ok {
 			return time.Time{}, fmt.Errorf("%w: %T", ErrInvalidPipelineOperation, op)
 		}
+		
+		var err error
+		result, *status, err = applyOperation(result, opNode.Op, opNode.Value, loc, opts)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if IsTimezoneOperation(opNode.Op) {
+			loc = result.Location()
+		}
 	}
 	
 	return result, nil
 }
 
+// HasTimezoneOperation reports whether the expression converts its result
+// to another location with a "tz" or "utc" operation.
+func HasTimezoneOperation(node ExprNode) bool {
+	switch n := node.(type) {
+	case *PipeNode:
+		for _, op := range n.Operations {
+			if opNode, ok := op.(*OperationNode); ok && IsTimezoneOperation(opNode.Op) {
+				return true
+			}
+		}
+		return HasTimezoneOperation(n.Base)
+	case *RangeNode:
+		return HasTimezoneOperation(n.Start) || HasTimezoneOperation(n.End)
+	default:
+		return false
+	}
+}
+
 // Evaluate evaluates a RangeNode.
 func (n *RangeNode) Evaluate(_ *EvalContext) (time.Time, error) {
 	// Range nodes don't evaluate to a single time, they need special handling
@@ -96,7 +186,14 @@ func EvaluateTransform(transform *TransformNode, beginTime, endTime time.Time, i
 			"$begin": beginTime,
 			"$end":   endTime,
 		},
-		Index: index,
+		Index:           index,
+		AbbrevPolicy:    ctx.AbbrevPolicy,
+		DSTPolicy:       ctx.DSTPolicy,
+		MonthOverflow:   ctx.MonthOverflow,
+		Week:            ctx.Week,
+		FiscalYearStart: ctx.FiscalYearStart,
+		Retail:          ctx.Retail,
+		Calendars:       ctx.Calendars,
 	}
 	
 	// Evaluate begin expression
@@ -148,4 +245,27 @@ func EvaluateRangeExpression(input string, tz *time.Location) (time.Time, time.T
 	}
 	
 	return EvaluateRange(node, ctx)
-}
\ No newline at end of file
+}
+
+// dateValueWall returns the wall clock a date value asked for. The value is
+// parsed again in UTC, where no wall clock is skipped, to find it. It
+// reports false for values relative to now, which are instants.
+func dateValueWall(value string, ctx *EvalContext) (time.Time, bool) {
+	if strings.HasPrefix(value, "+") || strings.HasPrefix(value, "-") || strings.EqualFold(value, "now") {
+		return time.Time{}, false
+	}
+
+	now := ctx.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if ctx.Timezone != nil {
+		now = now.In(ctx.Timezone)
+	}
+	wallCtx := &EvalContext{Now: wallClockInUTC(now), Timezone: time.UTC, AbbrevPolicy: ctx.AbbrevPolicy}
+	wall, err := ParseDateValue(value, wallCtx)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return wall, true
+}
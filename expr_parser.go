@@ -2,13 +2,23 @@ package calcdate
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"unicode"
 )
 
 // ExprParser parses date expressions.
 type ExprParser struct {
 	tokens []Token
 	pos    int
+
+	// Natural enables the natural-language front-end: phrases such as
+	// "3 days ago" or "next monday at 9am" are rewritten by ParseNatural
+	// before falling back to the expression syntax.
+	Natural bool
+	// Locale translates localized words ("demain", "lundi prochain") into
+	// English before parsing. Nil means English input.
+	Locale *Locale
 }
 
 // NewExprParser creates a new expression parser.
@@ -16,9 +26,46 @@ func NewExprParser(_ string) *ExprParser {
 	return &ExprParser{}
 }
 
+// translateExpression translates the words of input between its quoted
+// arguments, which are kept as is: the schedule of nextCron "0 9 1 Jan *" is
+// not a localized phrase. Like for the tokenizer, a quote starts an argument
+// only where a token can start, so "aujourd'hui" stays one word.
+func translateExpression(l *Locale, input string) string {
+	parts := []string{}
+	segment := 0
+	for i := 0; i < len(input); i++ {
+		quote := input[i]
+		if quote != '"' && quote != '\'' {
+			continue
+		}
+		if i > 0 && !unicode.IsSpace(rune(input[i-1])) && !strings.ContainsRune("|,(", rune(input[i-1])) {
+			continue
+		}
+		end := strings.IndexByte(input[i+1:], quote)
+		if end < 0 {
+			break
+		}
+		parts = append(parts, l.Translate(input[segment:i]), input[i:i+end+2])
+		i += end + 1
+		segment = i + 1
+	}
+	parts = append(parts, l.Translate(input[segment:]))
+	return strings.Join(slices.DeleteFunc(parts, func(part string) bool { return part == "" }), " ")
+}
+
 // Parse parses a date expression string
 //nolint:ireturn // returns interface by design for AST nodes
 func (p *ExprParser) Parse(input string) (ExprNode, error) {
+	if p.Locale != nil && p.Locale.Name != LocaleEN.Name {
+		input = translateExpression(p.Locale, input)
+	}
+	
+	if p.Natural {
+		if node, err := ParseNatural(input); err == nil {
+			return node, nil
+		}
+	}
+	
 	// Tokenize input
 	tokenizer := NewTokenizer(input)
 	tokens, err := tokenizer.Tokenize()
@@ -29,7 +76,15 @@ func (p *ExprParser) Parse(input string) (ExprNode, error) {
 	p.tokens = tokens
 	p.pos = 0
 	
-	return p.parseExpression()
+	node, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	// Tokens left over would otherwise be silently dropped
+	if token := p.current(); token.Type != TokenEOF {
+		return nil, fmt.Errorf("%w: %v", ErrUnexpectedToken, token)
+	}
+	return node, nil
 }
 
 // ParseTransform parses a transform expression with comma-separated begin and end expressions.
@@ -87,6 +142,15 @@ func (p *ExprParser) parseRangeExpression(startNode ExprNode) (ExprNode, error)
 	if err != nil {
 		return nil, err
 	}
+	// Operations without pipe belong to the end ("today...today +7d"); a
+	// pipeline applies to the range.
+	endOps, err := p.parseOperationsWithoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if len(endOps) > 0 {
+		endNode = &PipeNode{Base: endNode, Operations: endOps}
+	}
 	rangeNode := &RangeNode{Start: startNode, End: endNode}
 	
 	// Check for pipe operations after the range
@@ -99,16 +163,16 @@ func (p *ExprParser) parseRangeExpression(startNode ExprNode) (ExprNode, error)
 
 //nolint:ireturn // returns interface by design for AST nodes
 func (p *ExprParser) parseOperationsAfterNode(node ExprNode) (ExprNode, error) {
-	ops := []ExprNode{}
-	for p.isOperationToken() {
-		op, err := p.parseOperation()
-		if err != nil {
-			return nil, err
-		}
-		ops = append(ops, op)
+	ops, err := p.parseOperationsWithoutPipe()
+	if err != nil {
+		return nil, err
 	}
 	
 	if len(ops) > 0 {
+		// The operations belong to the start of a range ("now -2h...now")
+		if p.current().Type == TokenRange {
+			return p.parseRangeExpression(&PipeNode{Base: node, Operations: ops})
+		}
 		// Check if there's a pipe after the operations
 		if p.current().Type == TokenPipe {
 			// Create a pipe node with the operations collected so far
@@ -122,6 +186,20 @@ func (p *ExprParser) parseOperationsAfterNode(node ExprNode) (ExprNode, error) {
 	return node, nil
 }
 
+// parseOperationsWithoutPipe parses the operations following a date up to
+// the next pipe or range operator.
+func (p *ExprParser) parseOperationsWithoutPipe() ([]ExprNode, error) {
+	ops := []ExprNode{}
+	for p.isOperationToken() {
+		op, err := p.parseOperation()
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
 func (p *ExprParser) isOperationToken() bool {
 	t := p.current().Type
 	return t == TokenOperator || t == TokenUnit || t == TokenKeyword
@@ -151,6 +229,10 @@ func (p *ExprParser) parsePipeline(base ExprNode) (ExprNode, error) {
 		}
 	}
 	
+	// The pipeline computes the start of a range ("now | trunc hour...now")
+	if p.current().Type == TokenRange {
+		return p.parseRangeExpression(&PipeNode{Base: base, Operations: operations})
+	}
 	return &PipeNode{Base: base, Operations: operations}, nil
 }
 
@@ -169,7 +251,7 @@ func (p *ExprParser) parsePrimary() (ExprNode, error) {
 		return p.parseOperatorUnitToken(token)
 	case TokenEOF:
 		return nil, ErrUnexpectedEndOfExpression
-	case TokenPipe, TokenRange, TokenNumber, TokenComma, TokenLParen, TokenRParen:
+	case TokenPipe, TokenRange, TokenNumber, TokenComma, TokenLParen, TokenRParen, TokenString:
 		return nil, fmt.Errorf("%w: %v", ErrUnexpectedToken, token)
 	default:
 		return nil, fmt.Errorf("%w: %v", ErrUnexpectedToken, token)
@@ -222,7 +304,7 @@ func (p *ExprParser) parseOperation() (ExprNode, error) {
 	case TokenKeyword:
 		return p.parseKeywordOperation(token)
 	case TokenEOF, TokenDate, TokenPipe, TokenRange, TokenVariable,
-		TokenNumber, TokenTime, TokenComma, TokenLParen, TokenRParen:
+		TokenNumber, TokenTime, TokenComma, TokenLParen, TokenRParen, TokenString:
 		return nil, fmt.Errorf("%w: got %v", ErrExpectedOperationAfterPipe, token)
 	default:
 		return nil, fmt.Errorf("%w: got %v", ErrExpectedOperationAfterPipe, token)
@@ -271,6 +353,24 @@ func (p *ExprParser) parseKeywordOperation(token Token) (ExprNode, error) {
 		return p.parseArgumentOperation(keyword)
 	}
 	
+	// Timezone conversions
+	if keyword == "tz" {
+		return p.parseTimezoneOperation()
+	}
+	if keyword == "utc" {
+		return &OperationNode{Op: keyword, Value: ""}, nil
+	}
+	
+	// Cron schedules: nextCron "0 9 * * 1-5"
+	if isCronOperation(keyword) {
+		return p.parseCronOperation(keyword)
+	}
+	
+	// Calendar events: nextEvent freeze
+	if isEventOperation(keyword) {
+		return p.parseEventOperation(keyword)
+	}
+	
 	// Boundary operations (startOf*, endOf*)
 	if p.isBoundaryOperation(keyword) {
 		return &OperationNode{Op: keyword, Value: ""}, nil
@@ -285,8 +385,11 @@ func (p *ExprParser) isArgumentOperation(keyword string) bool {
 		timeKeyword  = "time"
 		roundKeyword = "round"
 		truncKeyword = "trunc"
+		nextKeyword  = "next"
+		prevKeyword  = "prev"
 	)
-	return keyword == dayKeyword || keyword == timeKeyword || keyword == roundKeyword || keyword == truncKeyword
+	return keyword == dayKeyword || keyword == timeKeyword || keyword == roundKeyword || keyword == truncKeyword ||
+		keyword == nextKeyword || keyword == prevKeyword
 }
 
 func (p *ExprParser) isBoundaryOperation(keyword string) bool {
@@ -305,6 +408,39 @@ func (p *ExprParser) parseArgumentOperation(keyword string) (ExprNode, error) {
 	return &OperationNode{Op: keyword, Value: ""}, nil
 }
 
+//nolint:ireturn // returns interface by design for AST nodes
+func (p *ExprParser) parseTimezoneOperation() (ExprNode, error) {
+	token := p.current()
+	if token.Type != TokenDate && token.Type != TokenKeyword {
+		return nil, fmt.Errorf("%w: tz expects a timezone name, got %v", ErrInvalidTimezone, token)
+	}
+	p.advance()
+	return &OperationNode{Op: "tz", Value: token.Value}, nil
+}
+
+//nolint:ireturn // returns interface by design for AST nodes
+func (p *ExprParser) parseCronOperation(keyword string) (ExprNode, error) {
+	token := p.current()
+	if token.Type != TokenString {
+		return nil, fmt.Errorf("%w: %s expects a quoted schedule, got %v", ErrInvalidCron, canonicalKeyword(keyword), token)
+	}
+	p.advance()
+	if _, err := ParseCron(token.Value); err != nil {
+		return nil, err
+	}
+	return &OperationNode{Op: keyword, Value: token.Value}, nil
+}
+
+//nolint:ireturn // returns interface by design for AST nodes
+func (p *ExprParser) parseEventOperation(keyword string) (ExprNode, error) {
+	token := p.current()
+	if token.Type != TokenDate && token.Type != TokenKeyword && token.Type != TokenString {
+		return nil, fmt.Errorf("%w: %s expects a calendar name, got %v", ErrUnknownCalendar, canonicalKeyword(keyword), token)
+	}
+	p.advance()
+	return &OperationNode{Op: keyword, Value: token.Value}, nil
+}
+
 func (p *ExprParser) current() Token {
 	if p.pos >= len(p.tokens) {
 		return Token{Type: TokenEOF}
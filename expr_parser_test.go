@@ -29,6 +29,18 @@ func TestTokenizer(t *testing.T) {
 			input:    "2024-01-15...2024-01-31",
 			expected: []TokenType{TokenDate, TokenRange, TokenDate, TokenEOF},
 		},
+		{
+			input:    "now +1d12h30m",
+			expected: []TokenType{TokenKeyword, TokenUnit, TokenEOF},
+		},
+		{
+			input:    "+1.5h...+2w3d",
+			expected: []TokenType{TokenUnit, TokenRange, TokenUnit, TokenEOF},
+		},
+		{
+			input:    "now | tz America/New_York | utc",
+			expected: []TokenType{TokenKeyword, TokenPipe, TokenKeyword, TokenDate, TokenPipe, TokenKeyword, TokenEOF},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -168,6 +180,51 @@ func TestEvaluateExpression(t *testing.T) {
 	}
 }
 
+func TestRangeBoundsWithOperations(t *testing.T) {
+	testCases := []struct {
+		input, start, end string
+	}{
+		{"2024-01-15 -2d...2024-01-15", "2024-01-13", "2024-01-15"},
+		{"2024-01-15 -1d ... 2024-01-15 +1d", "2024-01-14", "2024-01-16"},
+		{"2024-01-15 | startOfMonth...2024-01-15", "2024-01-01", "2024-01-15"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			start, end, err := EvaluateRangeExpression(tc.input, time.UTC)
+			require.NoError(t, err)
+			assert.Equal(t, tc.start, start.Format(time.DateOnly))
+			assert.Equal(t, tc.end, end.Format(time.DateOnly))
+		})
+	}
+}
+
+func TestRangePipelineAfterEnd(t *testing.T) {
+	parser := NewExprParser("")
+
+	// a pipeline after the end applies to the range, not to its end
+	node, err := parser.Parse("2024-01-15...2024-01-20 | +1d")
+	require.NoError(t, err)
+	pipe, ok := node.(*PipeNode)
+	require.True(t, ok)
+	rangeNode, ok := pipe.Base.(*RangeNode)
+	require.True(t, ok)
+	assert.IsType(t, &DateNode{}, rangeNode.End)
+	require.Len(t, pipe.Operations, 1)
+
+	// operations without pipe stay on the end, the pipeline on the range
+	node, err = parser.Parse("2024-01-15...2024-01-20 +1d | endOfMonth")
+	require.NoError(t, err)
+	pipe, ok = node.(*PipeNode)
+	require.True(t, ok)
+	rangeNode, ok = pipe.Base.(*RangeNode)
+	require.True(t, ok)
+	endPipe, ok := rangeNode.End.(*PipeNode)
+	require.True(t, ok)
+	assert.Len(t, endPipe.Operations, 1)
+	assert.Equal(t, "endofmonth", pipe.Operations[0].(*OperationNode).Op)
+}
+
 func TestTransformParsing(t *testing.T) {
 	parser := NewExprParser("")
 	transform, err := parser.ParseTransform("$begin +8h, $end +20h")
@@ -226,4 +283,115 @@ func TestOperations(t *testing.T) {
 			tc.check(t, result)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestCompoundRelativeOffsets(t *testing.T) {
+	baseTime := time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		value    string
+		expected time.Time
+	}{
+		{value: "+1d12h30m", expected: time.Date(2024, 2, 1, 22, 30, 0, 0, time.UTC)},
+		{value: "-2w3d", expected: time.Date(2024, 1, 14, 10, 0, 0, 0, time.UTC)},
+		{value: "+1.5h", expected: time.Date(2024, 1, 31, 11, 30, 0, 0, time.UTC)},
+		{value: "+1.5d", expected: time.Date(2024, 2, 1, 22, 0, 0, 0, time.UTC)},
+		{value: "+1.5Y", expected: time.Date(2025, 7, 31, 10, 0, 0, 0, time.UTC)},
+		// Calendar order: months are added before days regardless of how they are written
+		{value: "+1d1M", expected: time.Date(2024, 3, 3, 10, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			result, err := applyRelativeDate(baseTime, tc.value, time.UTC)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+
+	_, err := applyRelativeDate(baseTime, "+1.5M", time.UTC)
+	require.ErrorIs(t, err, ErrInvalidNumberFormat)
+
+	_, err = applyRelativeDate(baseTime, "+1x", time.UTC)
+	require.ErrorIs(t, err, ErrUnknownUnit)
+}
+
+func TestParseIntervalCompound(t *testing.T) {
+	testCases := []struct {
+		interval string
+		expected time.Duration
+	}{
+		{interval: "1d12h", expected: 36 * time.Hour},
+		{interval: "1.5d", expected: 36 * time.Hour},
+		{interval: "1w1d", expected: 8 * 24 * time.Hour},
+		{interval: "1h30m", expected: 90 * time.Minute},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.interval, func(t *testing.T) {
+			dur, err := ParseInterval(tc.interval)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, dur)
+		})
+	}
+
+	_, err := ParseInterval("1M")
+	require.ErrorIs(t, err, ErrInvalidInterval)
+}
+
+func TestIsCalendarInterval(t *testing.T) {
+	for _, interval := range []string{"1M", "2q", "1Y", "1M15d", "1fq", "1fY", "1rp"} {
+		assert.True(t, IsCalendarInterval(interval), interval)
+	}
+	for _, interval := range []string{"", "1d", "1h30m", "1.5h", "15m", "1ms", "p1d", "1x"} {
+		assert.False(t, IsCalendarInterval(interval), interval)
+	}
+}
+
+func TestTimezoneOperations(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	ctx := &EvalContext{Now: time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC), Timezone: paris}
+
+	// 9am in Tokyo: operations after "tz" work in the new location
+	node, err := NewExprParser("").Parse("2024-03-10 | tz Asia/Tokyo | time 09:00")
+	require.NoError(t, err)
+	result, err := node.Evaluate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 10, 9, 0, 0, 0, tokyo), result)
+	assert.Equal(t, tokyo, result.Location())
+	assert.True(t, HasTimezoneOperation(node))
+
+	node, err = NewExprParser("").Parse("2024-03-10 12:00:00 | utc")
+	require.NoError(t, err)
+	result, err = node.Evaluate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 10, 11, 0, 0, 0, time.UTC), result)
+	assert.Equal(t, time.UTC, result.Location())
+
+	node, err = NewExprParser("").Parse("now | tz America/Port-au-Prince")
+	require.NoError(t, err)
+	assert.Equal(t, "now | tz America/Port-au-Prince", exprString(node))
+
+	// trunc and round work on the wall clock of half-hour zones
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	require.NoError(t, err)
+	node, err = NewExprParser("").Parse("2025-01-15 12:00:00 | tz Asia/Kolkata | trunc hour")
+	require.NoError(t, err)
+	result, err = node.Evaluate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 15, 16, 0, 0, 0, kolkata), result)
+	node, err = NewExprParser("").Parse("2025-01-15 12:00:00 | tz Asia/Kolkata | round hour")
+	require.NoError(t, err)
+	result, err = node.Evaluate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 15, 17, 0, 0, 0, kolkata), result)
+
+	_, err = ApplyOperation(ctx.Now, "tz", "Nowhere/Zone", paris)
+	require.ErrorIs(t, err, ErrInvalidTimezone)
+	_, err = ApplyOperation(ctx.Now, "tz", "", paris)
+	require.ErrorIs(t, err, ErrInvalidTimezone)
+}
@@ -24,6 +24,7 @@ const (
 	TokenComma
 	TokenLParen
 	TokenRParen
+	TokenString
 )
 
 // Token represents a lexical token.
@@ -110,6 +111,8 @@ func (t *Tokenizer) handleSpecialChar(ch byte, startPos int) error {
 		return t.readVariable()
 	case '.':
 		return t.handleDotToken(startPos)
+	case '"', '\'':
+		return t.readString(ch, startPos)
 	default:
 		if unicode.IsDigit(rune(ch)) {
 			return t.readDateOrNumberWithUnit()
@@ -139,6 +142,19 @@ func (t *Tokenizer) handleDotToken(startPos int) error {
 	return fmt.Errorf("%w: '.' at position %d", ErrUnexpectedCharacter, t.pos)
 }
 
+// readString reads a quoted argument such as the schedule of
+// nextCron "0 9 * * 1-5"; the token value excludes the quotes.
+func (t *Tokenizer) readString(quote byte, startPos int) error {
+	end := strings.IndexByte(t.input[t.pos+1:], quote)
+	if end < 0 {
+		return fmt.Errorf("%w: unterminated string at position %d", ErrUnexpectedCharacter, startPos)
+	}
+	value := t.input[t.pos+1 : t.pos+1+end]
+	t.tokens = append(t.tokens, Token{Type: TokenString, Value: value, Pos: startPos})
+	t.pos += end + 2
+	return nil
+}
+
 func (t *Tokenizer) readVariable() error {
 	startPos := t.pos
 	t.pos++ // Skip $
@@ -157,8 +173,17 @@ func (t *Tokenizer) readNumberWithUnit() error {
 	
 	t.readSign()
 	t.readDigits()
+	t.readFraction()
 	t.readUnit()
 	
+	// Compound offsets like "1d12h30m" chain further number/unit pairs
+	for t.pos > startPos && t.isUnitChar(t.input[t.pos-1]) &&
+		t.pos < len(t.input) && unicode.IsDigit(rune(t.input[t.pos])) {
+		t.readDigits()
+		t.readFraction()
+		t.readUnit()
+	}
+	
 	value := t.input[startPos:t.pos]
 	t.tokens = append(t.tokens, Token{Type: TokenUnit, Value: value, Pos: startPos})
 	return nil
@@ -176,6 +201,13 @@ func (t *Tokenizer) readDigits() {
 	}
 }
 
+func (t *Tokenizer) readFraction() {
+	if t.pos+1 < len(t.input) && t.input[t.pos] == '.' && unicode.IsDigit(rune(t.input[t.pos+1])) {
+		t.pos++
+		t.readDigits()
+	}
+}
+
 func (t *Tokenizer) readUnit() {
 	if t.pos < len(t.input) && t.isUnitChar(t.input[t.pos]) {
 		t.pos++
@@ -244,27 +276,41 @@ func (t *Tokenizer) readOptionalTimePart() bool {
 }
 
 func (t *Tokenizer) tryReadTimePart() bool {
-	const timePatternOffset = 3
-	const timePartLength = 8
-	
-	// Verify it's actually a time (HH:MM pattern) before consuming
-	if t.pos+timePatternOffset <= len(t.input) && t.input[t.pos+timePatternOffset] == ':' {
-		t.pos++
-		if t.pos+timePartLength <= len(t.input) {
-			t.pos += timePartLength
-		}
-		return true
+	// Verify it's actually a time (HH:MM pattern) before consuming the separator
+	clockLength := t.clockLength(t.pos + 1)
+	if clockLength == 0 {
+		return false
 	}
-	
-	if t.input[t.pos] == 'T' {
-		t.pos++
-		if t.pos+timePartLength <= len(t.input) {
-			t.pos += timePartLength
+	t.pos += 1 + clockLength
+	return true
+}
+
+// clockLength returns the length of the HH:MM or HH:MM:SS clock starting at
+// pos, or 0 if there is none.
+func (t *Tokenizer) clockLength(pos int) int {
+	const (
+		shortClock = len("15:04")
+		longClock  = len("15:04:05")
+	)
+	isClock := func(length int) bool {
+		if pos+length > len(t.input) {
+			return false
+		}
+		for i, ch := range []byte(t.input[pos : pos+length]) {
+			if (i == 2 || i == 5) != (ch == ':') || (ch != ':' && !unicode.IsDigit(rune(ch))) {
+				return false
+			}
 		}
 		return true
 	}
-	
-	return false
+	switch {
+	case isClock(longClock):
+		return longClock
+	case isClock(shortClock):
+		return shortClock
+	default:
+		return 0
+	}
 }
 
 func (t *Tokenizer) readOptionalTimezone() {
@@ -314,6 +360,10 @@ func (t *Tokenizer) readOptionalTimezoneName() {
 }
 
 func (t *Tokenizer) tryReadTimezoneName() bool {
+	if t.tryReadLocationName() {
+		return true
+	}
+
 	startPos := t.pos
 	
 	const maxTimezoneNameLength = 5
@@ -341,22 +391,28 @@ func (t *Tokenizer) tryReadTimezoneName() bool {
 	return false
 }
 
-func (t *Tokenizer) isValidTimezoneName(name string) bool {
-	// Common timezone abbreviations and names
-	validTimezones := []string{
-		"UTC", "GMT", "EST", "CST", "MST", "PST", "EDT", "CDT", "MDT", "PDT",
-		"CET", "CEST", "JST", "IST", "BST", "AEST", "AEDT",
+// tryReadLocationName reads an IANA location name such as "Europe/Paris"
+// or "America/Port-au-Prince"; it is resolved, and reported if unknown, with
+// the date it follows.
+func (t *Tokenizer) tryReadLocationName() bool {
+	startPos := t.pos
+	if t.pos >= len(t.input) || !unicode.IsLetter(rune(t.input[t.pos])) {
+		return false
 	}
-	
-	upperName := strings.ToUpper(name)
-	for _, tz := range validTimezones {
-		if upperName == tz {
-			return true
-		}
+	for t.pos < len(t.input) && t.isWordChar(startPos) {
+		t.pos++
+	}
+	if strings.Contains(t.input[startPos:t.pos], "/") {
+		return true
 	}
+	t.pos = startPos
 	return false
 }
 
+func (t *Tokenizer) isValidTimezoneName(name string) bool {
+	return IsTimezoneAbbreviation(name)
+}
+
 func (t *Tokenizer) isTime() bool {
 	// Check for HH:MM or HH:MM:SS pattern
 	if t.pos+5 > len(t.input) {
@@ -391,8 +447,9 @@ func (t *Tokenizer) readTime() error {
 func (t *Tokenizer) readKeywordOrDate() error {
 	startPos := t.pos
 	
-	// Read word
-	for t.pos < len(t.input) && (unicode.IsLetter(rune(t.input[t.pos])) || unicode.IsDigit(rune(t.input[t.pos]))) {
+	// Read word; '/' and '_' allow timezone names like "America/New_York",
+	// and '-' is accepted once a '/' was seen ("America/Port-au-Prince")
+	for t.pos < len(t.input) && t.isWordChar(startPos) {
 		t.pos++
 	}
 	
@@ -400,19 +457,7 @@ func (t *Tokenizer) readKeywordOrDate() error {
 	lowerValue := strings.ToLower(value)
 	
 	// Check if it's a keyword
-	keywords := []string{
-		"today", "now", "yesterday", "tomorrow",
-		"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday",
-		"start", "end", "startOf", "endOf",
-		"startOfDay", "endOfDay", "startOfWeek", "endOfWeek",
-		"startOfMonth", "endOfMonth", "startOfYear", "endOfYear",
-		"startOfQuarter", "endOfQuarter",
-		"startOfHour", "endOfHour", "startOfMinute", "endOfMinute", "startOfSecond", "endOfSecond",
-		"round", "trunc", "day", "time", "month", "year", "week", "quarter",
-		"hour", "minute", "second",
-	}
-	
-	for _, kw := range keywords {
+	for _, kw := range expressionKeywords {
 		if lowerValue == strings.ToLower(kw) {
 			t.tokens = append(t.tokens, Token{Type: TokenKeyword, Value: lowerValue, Pos: startPos})
 			return nil
@@ -424,6 +469,47 @@ func (t *Tokenizer) readKeywordOrDate() error {
 	return nil
 }
 
+func (t *Tokenizer) isWordChar(startPos int) bool {
+	ch := rune(t.input[t.pos])
+	switch {
+	case unicode.IsLetter(ch) || unicode.IsDigit(ch) || ch == '/' || ch == '_':
+		return true
+	case ch == '-':
+		return strings.Contains(t.input[startPos:t.pos], "/") &&
+			t.pos+1 < len(t.input) && unicode.IsLetter(rune(t.input[t.pos+1]))
+	default:
+		return false
+	}
+}
+
+// expressionKeywords lists the keywords of the expression language in their
+// canonical spelling. Keywords are matched case-insensitively.
+var expressionKeywords = []string{
+	"today", "now", "yesterday", "tomorrow",
+	"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday",
+	"start", "end", "startOf", "endOf",
+	"startOfDay", "endOfDay", "startOfWeek", "endOfWeek",
+	"startOfMonth", "endOfMonth", "startOfYear", "endOfYear",
+	"startOfQuarter", "endOfQuarter",
+	"startOfFiscalYear", "endOfFiscalYear", "startOfFiscalQuarter", "endOfFiscalQuarter",
+	"startOfPeriod", "endOfPeriod",
+	"startOfHour", "endOfHour", "startOfMinute", "endOfMinute", "startOfSecond", "endOfSecond",
+	"round", "trunc", "day", "time", "month", "year", "week", "quarter",
+	"hour", "minute", "second", "next", "prev", "tz", "utc",
+	"nextCron", "prevCron", "nextEvent", "prevEvent",
+}
+
+// canonicalKeyword returns the canonical spelling of a lower-cased keyword,
+// e.g. "startOfMonth" for "startofmonth".
+func canonicalKeyword(keyword string) string {
+	for _, kw := range expressionKeywords {
+		if strings.EqualFold(kw, keyword) {
+			return kw
+		}
+	}
+	return keyword
+}
+
 // minInt returns the minimum of two integers.
 func minInt(a, b int) int {
 	if a < b {
@@ -0,0 +1,133 @@
+package calcdate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FiscalCalendar is a fiscal year starting on the first day of a month and
+// split into four quarters of three months.
+type FiscalCalendar struct {
+	// StartMonth is the first month of the fiscal year; zero means January.
+	StartMonth time.Month
+}
+
+func (f FiscalCalendar) startMonth() time.Month {
+	if f.StartMonth == 0 {
+		return time.January
+	}
+	return f.StartMonth
+}
+
+// monthsIntoYear returns the number of whole months between the start of
+// the fiscal year containing t and the month of t (0 to 11).
+func (f FiscalCalendar) monthsIntoYear(t time.Time) int {
+	return (int(t.Month()) - int(f.startMonth()) + MonthsInYear) % MonthsInYear
+}
+
+// Year returns the fiscal year of t, named after the calendar year it ends
+// in: with an April start, April 2024 to March 2025 is fiscal year 2025.
+func (f FiscalCalendar) Year(t time.Time) int {
+	start := f.StartOfYear(t)
+	if f.startMonth() == time.January {
+		return start.Year()
+	}
+	return start.Year() + 1
+}
+
+// Quarter returns the fiscal quarter of t, 1 to 4.
+func (f FiscalCalendar) Quarter(t time.Time) int {
+	return f.monthsIntoYear(t)/MonthsInQuarter + 1
+}
+
+// StartOfYear returns the start of the fiscal year containing t.
+func (f FiscalCalendar) StartOfYear(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month()-time.Month(f.monthsIntoYear(t)), 1, 0, 0, 0, 0, t.Location())
+}
+
+// EndOfYear returns the end of the fiscal year containing t.
+func (f FiscalCalendar) EndOfYear(t time.Time) time.Time {
+	next := f.StartOfYear(t).AddDate(0, MonthsInYear, 0)
+	return endOfDay(next.AddDate(0, 0, -1), t.Location())
+}
+
+// StartOfQuarter returns the start of the fiscal quarter containing t.
+func (f FiscalCalendar) StartOfQuarter(t time.Time) time.Time {
+	monthsIntoQuarter := f.monthsIntoYear(t) % MonthsInQuarter
+	return time.Date(t.Year(), t.Month()-time.Month(monthsIntoQuarter), 1, 0, 0, 0, 0, t.Location())
+}
+
+// EndOfQuarter returns the end of the fiscal quarter containing t.
+func (f FiscalCalendar) EndOfQuarter(t time.Time) time.Time {
+	next := f.StartOfQuarter(t).AddDate(0, MonthsInQuarter, 0)
+	return endOfDay(next.AddDate(0, 0, -1), t.Location())
+}
+
+// ParseFiscalYearStart parses the first month of a fiscal year: a number
+// (1-12) or a month name in the locale or in English ("april", "avril", "Apr").
+func ParseFiscalYearStart(value string, locale *Locale) (time.Month, error) {
+	value = strings.TrimSpace(value)
+	if n, err := strconv.Atoi(value); err == nil {
+		if n < 1 || n > MonthsInYear {
+			return 0, fmt.Errorf("%w: %s", ErrInvalidMonth, value)
+		}
+		return time.Month(n), nil
+	}
+	if locale == nil {
+		locale = LocaleEN
+	}
+	month, ok := locale.ParseMonth(value)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidMonth, value)
+	}
+	return month, nil
+}
+
+func applyFiscalBoundaryOps(date time.Time, op string, fiscal FiscalCalendar) (operationResult, bool) {
+	switch op {
+	case "startoffiscalyear":
+		return operationResult{fiscal.StartOfYear(date), nil}, true
+	case "endoffiscalyear":
+		return operationResult{fiscal.EndOfYear(date), nil}, true
+	case "startoffiscalquarter":
+		return operationResult{fiscal.StartOfQuarter(date), nil}, true
+	case "endoffiscalquarter":
+		return operationResult{fiscal.EndOfQuarter(date), nil}, true
+	default:
+		return operationResult{}, false
+	}
+}
+
+// fiscalIntervalMonths returns the length in months of a fiscal interval
+// such as "1fq" or "2fY". Fiscal units cannot be combined with others.
+func fiscalIntervalMonths(components []relativeComponent) (int, bool, error) {
+	isFiscal := false
+	for _, c := range components {
+		if strings.HasPrefix(c.unit, "f") {
+			isFiscal = true
+		}
+	}
+	if !isFiscal {
+		return 0, false, nil
+	}
+	if len(components) != 1 {
+		return 0, true, fmt.Errorf("%w: fiscal units cannot be combined", ErrInvalidInterval)
+	}
+
+	c := components[0]
+	var months float64
+	switch c.unit {
+	case "fq":
+		months = c.value * MonthsInQuarter
+	case "fY":
+		months = c.value * MonthsInYear
+	default:
+		return 0, true, fmt.Errorf("%w: unknown fiscal unit %s", ErrInvalidInterval, c.unit)
+	}
+	if months < 1 || c.value != float64(int(c.value)) {
+		return 0, true, fmt.Errorf("%w: %g%s", ErrInvalidInterval, c.value, c.unit)
+	}
+	return int(months), true, nil
+}
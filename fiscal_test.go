@@ -0,0 +1,115 @@
+package calcdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFiscalCalendar(t *testing.T) {
+	april := FiscalCalendar{StartMonth: time.April}
+	calendar := FiscalCalendar{}
+
+	tests := []struct {
+		name            string
+		fiscal          FiscalCalendar
+		date            time.Time
+		year, quarter   int
+		startOfYear     string
+		endOfYear       string
+		startOfQuarter  string
+		endOfQuarterDay string
+	}{
+		{"april start, february", april, time.Date(2025, 2, 10, 9, 0, 0, 0, time.UTC),
+			2025, 4, "2024-04-01", "2025-03-31", "2025-01-01", "2025-03-31"},
+		{"april start, april", april, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+			2026, 1, "2025-04-01", "2026-03-31", "2025-04-01", "2025-06-30"},
+		{"april start, december", april, time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+			2026, 3, "2025-04-01", "2026-03-31", "2025-10-01", "2025-12-31"},
+		{"calendar year", calendar, time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC),
+			2025, 3, "2025-01-01", "2025-12-31", "2025-07-01", "2025-09-30"},
+		{"october start", FiscalCalendar{StartMonth: time.October}, time.Date(2024, 11, 5, 0, 0, 0, 0, time.UTC),
+			2025, 1, "2024-10-01", "2025-09-30", "2024-10-01", "2024-12-31"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.year, tc.fiscal.Year(tc.date))
+			assert.Equal(t, tc.quarter, tc.fiscal.Quarter(tc.date))
+			assert.Equal(t, tc.startOfYear+" 00:00:00", tc.fiscal.StartOfYear(tc.date).Format(time.DateTime))
+			assert.Equal(t, tc.endOfYear+" 23:59:59", tc.fiscal.EndOfYear(tc.date).Format(time.DateTime))
+			assert.Equal(t, tc.startOfQuarter+" 00:00:00", tc.fiscal.StartOfQuarter(tc.date).Format(time.DateTime))
+			assert.Equal(t, tc.endOfQuarterDay+" 23:59:59", tc.fiscal.EndOfQuarter(tc.date).Format(time.DateTime))
+		})
+	}
+}
+
+func TestFiscalOperations(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected string
+	}{
+		{"2025-02-10 | startOfFiscalYear", "2024-04-01 00:00:00"},
+		{"2025-02-10 | endOfFiscalYear", "2025-03-31 23:59:59"},
+		{"2025-05-20 | startOfFiscalQuarter", "2025-04-01 00:00:00"},
+		{"2025-05-20 | endoffiscalquarter", "2025-06-30 23:59:59"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			node, err := NewExprParser("").Parse(tc.expr)
+			require.NoError(t, err)
+			result, err := node.Evaluate(&EvalContext{Timezone: time.UTC, FiscalYearStart: time.April})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result.Format(time.DateTime))
+		})
+	}
+}
+
+func TestFiscalIteration(t *testing.T) {
+	start := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := &EvalContext{Timezone: time.UTC, FiscalYearStart: time.April}
+
+	results, err := IterateWithSpecialIntervalContext(start, end, "1fq", nil, ctx)
+	require.NoError(t, err)
+	ends := []string{}
+	for _, r := range results {
+		ends = append(ends, r.EndTime.Format(time.DateOnly))
+	}
+	assert.Equal(t, []string{"2025-04-01", "2025-07-01", "2025-10-01", "2026-01-01"}, ends)
+
+	results, err = IterateWithSpecialIntervalContext(start, end, "1fY", nil, ctx)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "2025-04-01", results[0].EndTime.Format(time.DateOnly))
+
+	_, err = IterateWithSpecialIntervalContext(start, end, "1fq1d", nil, ctx)
+	require.ErrorIs(t, err, ErrInvalidInterval)
+}
+
+func TestFiscalDirectives(t *testing.T) {
+	f := Formatter{Fiscal: FiscalCalendar{StartMonth: time.April}}
+	date := time.Date(2025, 5, 20, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "FY2026-Q1 2025-05", f.Format(date, "FY%FY-Q%FQ %Y-%m"))
+	assert.Equal(t, "%Fx", f.Format(date, "%Fx"))
+}
+
+func TestParseFiscalYearStart(t *testing.T) {
+	for value, expected := range map[string]time.Month{"april": time.April, "Apr": time.April, "10": time.October} {
+		month, err := ParseFiscalYearStart(value, nil)
+		require.NoError(t, err, value)
+		assert.Equal(t, expected, month, value)
+	}
+
+	month, err := ParseFiscalYearStart("avril", LocaleFR)
+	require.NoError(t, err)
+	assert.Equal(t, time.April, month)
+
+	for _, value := range []string{"13", "0", "smarch"} {
+		_, err := ParseFiscalYearStart(value, nil)
+		require.ErrorIs(t, err, ErrInvalidMonth, value)
+	}
+}
@@ -0,0 +1,99 @@
+package calcdate
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formatter renders times with Unix date format directives (%Y, %m, %d, %H...).
+// Unlike ConvertUnixFormatToGolang, literal text is never interpreted as a Go
+// layout, and names (%a, %A, %b, %B, %p) come from the configured Locale.
+type Formatter struct {
+	// Locale provides weekday, month and AM/PM names. Nil means English.
+	Locale *Locale
+	// Fiscal provides the fiscal year (%FY) and quarter (%FQ).
+	Fiscal FiscalCalendar
+}
+
+// Format renders t according to a Unix date format. Unknown directives are
+// copied unchanged. %-d, %-m, %-H, %-I, %-M and %-S print numbers without padding.
+// %FY and %FQ print the fiscal year (e.g. 2025) and quarter (1 to 4).
+func (f Formatter) Format(t time.Time, format string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i+1 >= len(format) {
+			b.WriteByte(format[i])
+			continue
+		}
+
+		directive := format[i+1 : i+2]
+		if (directive == "-" || directive == "F") && i+2 < len(format) {
+			directive = format[i+1 : i+3]
+		}
+
+		if s, ok := f.directive(t, directive); ok {
+			b.WriteString(s)
+			i += len(directive)
+			continue
+		}
+		b.WriteByte(format[i])
+	}
+	return b.String()
+}
+
+// Human renders t in the locale's long human-readable form.
+func (f Formatter) Human(t time.Time) string {
+	return f.Format(t, f.locale().HumanFormat)
+}
+
+func (f Formatter) locale() *Locale {
+	if f.Locale == nil {
+		return LocaleEN
+	}
+	return f.Locale
+}
+
+//nolint:cyclop // one case per directive
+func (f Formatter) directive(t time.Time, directive string) (string, bool) {
+	const hoursPerHalfDay = 12
+
+	switch directive {
+	case "%":
+		return "%", true
+	case "a":
+		return f.locale().WeekdayName(t.Weekday(), true), true
+	case "A":
+		return f.locale().WeekdayName(t.Weekday(), false), true
+	case "b":
+		return f.locale().MonthName(t.Month(), true), true
+	case "B":
+		return f.locale().MonthName(t.Month(), false), true
+	case "p":
+		if t.Hour() < hoursPerHalfDay {
+			return f.locale().AM, true
+		}
+		return f.locale().PM, true
+	case "-d":
+		return strconv.Itoa(t.Day()), true
+	case "-m":
+		return strconv.Itoa(int(t.Month())), true
+	case "-H":
+		return strconv.Itoa(t.Hour()), true
+	case "-I":
+		return strconv.Itoa((t.Hour()+hoursPerHalfDay-1)%hoursPerHalfDay + 1), true
+	case "-M":
+		return strconv.Itoa(t.Minute()), true
+	case "-S":
+		return strconv.Itoa(t.Second()), true
+	case "FY":
+		return strconv.Itoa(f.Fiscal.Year(t)), true
+	case "FQ":
+		return strconv.Itoa(f.Fiscal.Quarter(t)), true
+	case "Y", "y", "m", "d", "H", "I", "M", "S", "z", "Z", "j":
+		return t.Format(ConvertUnixFormatToGolang("%" + directive)), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,49 @@
+//go:build ignore
+
+// gen_zonenames writes zonenames.txt, the zones of the tzdata shipped with
+// the Go toolchain running it: go generate ./...
+package main
+
+import (
+	"archive/zip"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+func main() {
+	goroot := os.Getenv("GOROOT")
+	if goroot == "" {
+		goroot = runtime.GOROOT() //nolint:staticcheck // only a fallback when not run by go generate
+	}
+	archive, err := zip.OpenReader(filepath.Join(goroot, "lib", "time", "zoneinfo.zip"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer archive.Close()
+
+	names := []string{}
+	for _, file := range archive.File {
+		if !file.FileInfo().IsDir() && isZoneName(file.Name) {
+			names = append(names, file.Name)
+		}
+	}
+	slices.Sort(names)
+	if err := os.WriteFile("zonenames.txt", []byte(strings.Join(names, "\n")+"\n"), 0o644); err != nil { //nolint:gosec // generated source file
+		log.Fatal(err)
+	}
+}
+
+// isZoneName keeps the names whose components start with a capital letter,
+// like systemZoneNames does.
+func isZoneName(name string) bool {
+	for _, component := range strings.Split(name, "/") {
+		if component == "" || component[0] < 'A' || component[0] > 'Z' || strings.Contains(component, ".") {
+			return false
+		}
+	}
+	return true
+}
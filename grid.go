@@ -0,0 +1,34 @@
+package calcdate
+
+import "time"
+
+// MonthGrid returns the weeks of the month containing t, in t's location,
+// as rows of days starting on week.Start, the way calendars print them.
+// The first and last rows are completed with days of the adjacent months.
+func MonthGrid(t time.Time, week Week) [][DaysInWeek]time.Time {
+	first := startOfMonth(t, t.Location())
+	day := week.StartOf(first)
+	rows := [][DaysInWeek]time.Time{}
+	for day.Month() == first.Month() || day.Before(first) {
+		var row [DaysInWeek]time.Time
+		for i := range row {
+			row[i] = day
+			day = day.AddDate(0, 0, 1)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// GridWeekNumber returns the ISO 8601 week number of a MonthGrid row: that
+// of its Thursday, the ISO week holding most of the row's days when weeks
+// do not start on Monday.
+func GridWeekNumber(row [DaysInWeek]time.Time) int {
+	for _, day := range row {
+		if day.Weekday() == time.Thursday {
+			_, week := day.ISOWeek()
+			return week
+		}
+	}
+	return 0
+}
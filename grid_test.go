@@ -0,0 +1,41 @@
+package calcdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonthGrid(t *testing.T) {
+	march := time.Date(2025, 3, 15, 10, 0, 0, 0, mustLoad(t, "Europe/Paris"))
+
+	rows := MonthGrid(march, ISOWeek)
+	require.Len(t, rows, 6)
+	assert.Equal(t, "2025-02-24", rows[0][0].Format(time.DateOnly))
+	assert.Equal(t, "2025-03-01", rows[0][5].Format(time.DateOnly))
+	assert.Equal(t, "2025-03-31", rows[5][0].Format(time.DateOnly))
+	assert.Equal(t, "2025-04-06", rows[5][6].Format(time.DateOnly))
+	// days keep their wall clock across the DST change of 30 March
+	assert.Equal(t, 0, rows[5][0].Hour())
+
+	rows = MonthGrid(march, USWeek)
+	require.Len(t, rows, 6)
+	assert.Equal(t, time.Sunday, rows[0][0].Weekday())
+	assert.Equal(t, "2025-03-30", rows[5][0].Format(time.DateOnly))
+
+	// February 2026 starts on a Sunday and fills four US weeks
+	assert.Len(t, MonthGrid(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), USWeek), 4)
+}
+
+func TestGridWeekNumber(t *testing.T) {
+	january := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := MonthGrid(january, ISOWeek)
+	assert.Equal(t, 53, GridWeekNumber(rows[0])) // 28 Dec 2026 - 3 Jan 2027
+	assert.Equal(t, 1, GridWeekNumber(rows[1]))
+
+	rows = MonthGrid(january, USWeek)
+	assert.Equal(t, 53, GridWeekNumber(rows[0])) // 27 Dec 2026 - 2 Jan 2027
+	assert.Equal(t, 1, GridWeekNumber(rows[1]))
+}
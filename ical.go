@@ -0,0 +1,195 @@
+package calcdate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ICalProductID is the PRODID of the iCalendar documents written by calcdate.
+const ICalProductID = "-//sgaunet//calcdate//EN"
+
+// iCalendar date-time layouts: local (with a TZID) and UTC.
+const (
+	icalLocalLayout = "20060102T150405"
+	icalUTCLayout   = "20060102T150405Z"
+)
+
+// icalLineLength is the maximum length of a content line, in octets.
+const icalLineLength = 75
+
+// ICalEvent is a VEVENT of an iCalendar document.
+type ICalEvent struct {
+	UID   string
+	Start time.Time
+	// End is the DTEND; the zero time writes no DTEND (an instant).
+	End         time.Time
+	Summary     string
+	Description string
+}
+
+// ICalUID returns a stable event UID derived from an expression and the
+// index of the result, so re-exporting the same expression updates the
+// same calendar events.
+func ICalUID(expr string, index int) string {
+	sum := sha256.Sum256([]byte(expr + "\x00" + strconv.Itoa(index)))
+	const uidBytes = 16
+	return hex.EncodeToString(sum[:uidBytes]) + "@calcdate"
+}
+
+// WriteICalendar writes events as a VCALENDAR document. Times are written in
+// loc with a VTIMEZONE describing its offsets over the events, or in UTC
+// when loc is UTC or Local (which has no portable name). stamp is the
+// DTSTAMP of the events.
+func WriteICalendar(w io.Writer, events []ICalEvent, loc *time.Location, stamp time.Time) error {
+	cw := &icalWriter{w: w}
+	cw.line("BEGIN:VCALENDAR")
+	cw.line("VERSION:2.0")
+	cw.line("PRODID:" + ICalProductID)
+	cw.line("CALSCALE:GREGORIAN")
+	cw.line("METHOD:PUBLISH")
+
+	tzid := ""
+	if loc != nil && loc != time.UTC && loc.String() != "UTC" && loc.String() != "Local" {
+		tzid = loc.String()
+		if start, end, ok := eventSpan(events); ok {
+			cw.timezone(loc, start, end)
+		}
+	}
+
+	for _, event := range events {
+		cw.line("BEGIN:VEVENT")
+		cw.line("UID:" + escapeICalText(event.UID))
+		cw.line("DTSTAMP:" + stamp.UTC().Format(icalUTCLayout))
+		cw.line(icalDateTime("DTSTART", event.Start, tzid, loc))
+		if !event.End.IsZero() {
+			cw.line(icalDateTime("DTEND", event.End, tzid, loc))
+		}
+		if event.Summary != "" {
+			cw.line("SUMMARY:" + escapeICalText(event.Summary))
+		}
+		if event.Description != "" {
+			cw.line("DESCRIPTION:" + escapeICalText(event.Description))
+		}
+		cw.line("END:VEVENT")
+	}
+	cw.line("END:VCALENDAR")
+	return cw.err
+}
+
+// eventSpan returns the earliest start and latest end of events.
+func eventSpan(events []ICalEvent) (time.Time, time.Time, bool) {
+	if len(events) == 0 {
+		return time.Time{}, time.Time{}, false
+	}
+	start, end := events[0].Start, events[0].Start
+	for _, event := range events {
+		if event.Start.Before(start) {
+			start = event.Start
+		}
+		for _, t := range []time.Time{event.Start, event.End} {
+			if t.After(end) {
+				end = t
+			}
+		}
+	}
+	return start, end, true
+}
+
+func icalDateTime(name string, t time.Time, tzid string, loc *time.Location) string {
+	if tzid == "" {
+		return name + ":" + t.UTC().Format(icalUTCLayout)
+	}
+	return name + ";TZID=" + tzid + ":" + t.In(loc).Format(icalLocalLayout)
+}
+
+// icalWriter writes folded CRLF content lines and keeps the first error.
+type icalWriter struct {
+	w   io.Writer
+	err error
+}
+
+// line writes a content line, folded every 75 octets without splitting
+// UTF-8 sequences.
+func (cw *icalWriter) line(content string) {
+	if cw.err != nil {
+		return
+	}
+	var b strings.Builder
+	width := 0
+	for _, r := range content {
+		size := len(string(r))
+		if width+size > icalLineLength {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	b.WriteString("\r\n")
+	_, cw.err = io.WriteString(cw.w, b.String())
+}
+
+// timezone writes a VTIMEZONE for loc covering [start, end]: the observance
+// in effect at start, then one observance per offset change.
+func (cw *icalWriter) timezone(loc *time.Location, start, end time.Time) {
+	cw.line("BEGIN:VTIMEZONE")
+	cw.line("TZID:" + loc.String())
+
+	first := start.In(loc)
+	cw.observance(first, first, time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC))
+	current := first
+	for range MaxIterations {
+		_, next := current.ZoneBounds()
+		if next.IsZero() || next.After(end) {
+			break
+		}
+		next = next.In(loc)
+		_, offsetBefore := next.Add(-time.Nanosecond).Zone()
+		onset := next.In(time.FixedZone("", offsetBefore))
+		cw.observance(next.Add(-time.Nanosecond), next, onset)
+		current = next
+	}
+	cw.line("END:VTIMEZONE")
+}
+
+// observance writes a STANDARD or DAYLIGHT component switching from the
+// offset of before to the offset of after, with onset as its local DTSTART.
+func (cw *icalWriter) observance(before, after, onset time.Time) {
+	kind := "STANDARD"
+	if after.IsDST() {
+		kind = "DAYLIGHT"
+	}
+	name, _ := after.Zone()
+	cw.line("BEGIN:" + kind)
+	cw.line("DTSTART:" + onset.Format(icalLocalLayout))
+	cw.line("TZOFFSETFROM:" + icalOffset(before))
+	cw.line("TZOFFSETTO:" + icalOffset(after))
+	cw.line("TZNAME:" + escapeICalText(name))
+	cw.line("END:" + kind)
+}
+
+// icalOffset formats the UTC offset of t as "+0100" (or "+053000" with seconds).
+func icalOffset(t time.Time) string {
+	_, offset := t.Zone()
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	hours := offset / (MinutesInHour * SecondsInMinute)
+	minutes, seconds := offset/SecondsInMinute%MinutesInHour, offset%SecondsInMinute
+	if seconds != 0 {
+		return fmt.Sprintf("%s%02d%02d%02d", sign, hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, hours, minutes)
+}
+
+// escapeICalText escapes a TEXT value: backslashes, semicolons, commas and
+// newlines.
+func escapeICalText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
@@ -0,0 +1,62 @@
+package calcdate
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteICalendarUTC(t *testing.T) {
+	start := time.Date(2025, 6, 13, 9, 0, 0, 0, time.UTC)
+	events := []ICalEvent{{
+		UID:         ICalUID("today...+7d", 0),
+		Start:       start,
+		End:         start.Add(time.Hour),
+		Summary:     "Maintenance; db, cache",
+		Description: strings.Repeat("long description ", 10),
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteICalendar(&buf, events, time.UTC, start))
+	doc := buf.String()
+
+	assert.True(t, strings.HasPrefix(doc, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:"+ICalProductID+"\r\n"))
+	assert.True(t, strings.HasSuffix(doc, "END:VEVENT\r\nEND:VCALENDAR\r\n"))
+	assert.NotContains(t, doc, "VTIMEZONE")
+	assert.Contains(t, doc, "DTSTART:20250613T090000Z\r\nDTEND:20250613T100000Z\r\n")
+	assert.Contains(t, doc, `SUMMARY:Maintenance\; db\, cache`)
+	for _, line := range strings.Split(doc, "\r\n") {
+		assert.LessOrEqual(t, len(line), 75, line)
+	}
+	assert.Contains(t, doc, "descrip\r\n tion")
+}
+
+func TestWriteICalendarTimezone(t *testing.T) {
+	paris := mustLoad(t, "Europe/Paris")
+	events := []ICalEvent{
+		{UID: "a", Start: time.Date(2025, 3, 1, 9, 0, 0, 0, paris)},
+		{UID: "b", Start: time.Date(2025, 6, 1, 9, 0, 0, 0, paris), End: time.Date(2025, 6, 1, 10, 0, 0, 0, paris)},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteICalendar(&buf, events, paris, time.Now()))
+	doc := strings.ReplaceAll(buf.String(), "\r\n", "\n")
+
+	assert.Contains(t, doc, "BEGIN:VTIMEZONE\nTZID:Europe/Paris\n"+
+		"BEGIN:STANDARD\nDTSTART:19700101T000000\nTZOFFSETFROM:+0100\nTZOFFSETTO:+0100\nTZNAME:CET\nEND:STANDARD\n"+
+		"BEGIN:DAYLIGHT\nDTSTART:20250330T020000\nTZOFFSETFROM:+0100\nTZOFFSETTO:+0200\nTZNAME:CEST\nEND:DAYLIGHT\n"+
+		"END:VTIMEZONE\n")
+	assert.Contains(t, doc, "UID:a\nDTSTAMP:")
+	assert.Contains(t, doc, "DTSTART;TZID=Europe/Paris:20250301T090000\nEND:VEVENT")
+	assert.Contains(t, doc, "DTEND;TZID=Europe/Paris:20250601T100000\n")
+}
+
+func TestICalUID(t *testing.T) {
+	assert.Equal(t, ICalUID("today...+7d", 3), ICalUID("today...+7d", 3))
+	assert.NotEqual(t, ICalUID("today...+7d", 3), ICalUID("today...+7d", 4))
+	assert.True(t, strings.HasSuffix(ICalUID("now", 0), "@calcdate"))
+}
@@ -0,0 +1,76 @@
+package calcdate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParsePipeline parses pipeline operations such as "| tz UTC | trunc hour",
+// the leading pipe being optional, to apply to other dates with
+// ApplyOperationsWithContext.
+func ParsePipeline(pipe string) ([]ExprNode, error) {
+	pipe = strings.TrimSpace(pipe)
+	if !strings.HasPrefix(pipe, "|") {
+		pipe = "| " + pipe
+	}
+	node, err := NewExprParser("").Parse("now " + pipe)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPipeline, err)
+	}
+	pipeNode, ok := node.(*PipeNode)
+	if !ok || len(pipeNode.Operations) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPipeline, pipe)
+	}
+	return pipeNode.Operations, nil
+}
+
+// ParseLayout parses value with an input layout: iso (RFC 3339), sql
+// (2006-01-02 15:04:05), ts (Unix seconds), compact (20060102), a Unix date
+// format such as "%d/%m/%Y %H:%M" or a Go layout. Values without an offset
+// are in loc.
+func ParseLayout(value, layout string, loc *time.Location) (time.Time, error) {
+	var t time.Time
+	var err error
+	switch layout {
+	case "ts":
+		var seconds int64
+		seconds, err = strconv.ParseInt(value, 10, 64)
+		t = time.Unix(seconds, 0).In(loc)
+	default:
+		t, err = time.ParseInLocation(GoLayout(layout), value, loc)
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q is not %s", ErrNoMatchingLayout, value, layout)
+	}
+	return t, nil
+}
+
+// ParseLayouts parses value with the first matching layout of ParseLayout,
+// and returns its index.
+func ParseLayouts(value string, layouts []string, loc *time.Location) (time.Time, int, error) {
+	for i, layout := range layouts {
+		if t, err := ParseLayout(value, layout, loc); err == nil {
+			return t, i, nil
+		}
+	}
+	return time.Time{}, -1, fmt.Errorf("%w: %q", ErrNoMatchingLayout, value)
+}
+
+// GoLayout returns the Go layout of the named layouts iso, sql and compact,
+// converts Unix date formats, and returns Go layouts unchanged.
+func GoLayout(layout string) string {
+	switch layout {
+	case "iso":
+		return time.RFC3339
+	case "sql":
+		return time.DateTime
+	case "compact":
+		return "20060102"
+	}
+	if strings.Contains(layout, "%") {
+		return ConvertUnixFormatToGolang(layout)
+	}
+	return layout
+}
@@ -0,0 +1,63 @@
+package calcdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePipeline(t *testing.T) {
+	paris := mustLoad(t, "Europe/Paris")
+	date := time.Date(2025, 3, 1, 10, 42, 13, 0, paris)
+
+	for _, pipe := range []string{"| tz UTC | trunc hour", "tz UTC | trunc hour"} {
+		operations, err := ParsePipeline(pipe)
+		require.NoError(t, err)
+		require.Len(t, operations, 2)
+		got, err := ApplyOperationsWithContext(date, operations, &EvalContext{Timezone: paris})
+		require.NoError(t, err)
+		assert.Equal(t, "2025-03-01T09:00:00Z", got.Format(time.RFC3339))
+	}
+
+	_, err := ParsePipeline("")
+	require.ErrorIs(t, err, ErrInvalidPipeline)
+	_, err = ParsePipeline("| 2025-01-01")
+	require.ErrorIs(t, err, ErrInvalidPipeline)
+}
+
+func TestParseLayout(t *testing.T) {
+	paris := mustLoad(t, "Europe/Paris")
+	tests := []struct {
+		value, layout, want string
+	}{
+		{"2025-03-01T10:00:00+05:00", "iso", "2025-03-01T10:00:00+05:00"},
+		{"2025-03-01 10:00:00", "sql", "2025-03-01T10:00:00+01:00"},
+		{"1700000000", "ts", "2023-11-14T23:13:20+01:00"},
+		{"20250301", "compact", "2025-03-01T00:00:00+01:00"},
+		{"01/03/2025 10:30", "%d/%m/%Y %H:%M", "2025-03-01T10:30:00+01:00"},
+		{"Mar 1 2025", "Jan 2 2006", "2025-03-01T00:00:00+01:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			got, err := ParseLayout(tt.value, tt.layout, paris)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Format(time.RFC3339))
+		})
+	}
+
+	_, err := ParseLayout("2025-03-01", "sql", paris)
+	require.ErrorIs(t, err, ErrNoMatchingLayout)
+}
+
+func TestParseLayouts(t *testing.T) {
+	layouts := []string{"sql", "iso", "ts"}
+	_, i, err := ParseLayouts("2025-03-01T10:00:00Z", layouts, time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, 1, i)
+
+	_, i, err = ParseLayouts("yesterday", layouts, time.UTC)
+	require.ErrorIs(t, err, ErrNoMatchingLayout)
+	assert.Equal(t, -1, i)
+}
@@ -0,0 +1,331 @@
+package calcdate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Locale holds the month and weekday names of a language. It is used to
+// format %a, %A, %b, %B and %p, to render the "human" output and to
+// translate localized input such as "demain" or "lundi prochain" into the
+// English phrases understood by ParseNatural.
+type Locale struct {
+	Name          string
+	Weekdays      [7]string // indexed by time.Weekday, Sunday first
+	ShortWeekdays [7]string
+	Months        [12]string // January first
+	ShortMonths   [12]string
+	AM, PM        string
+	// HumanFormat is the Unix date format used for the "human" output.
+	HumanFormat string
+	// Words maps localized words and phrases (lower-case, without accents)
+	// to their English equivalent. An empty translation drops the word.
+	Words map[string]string
+	// AgoPrefixes are phrases placed before a quantity to express the past
+	// ("il y a 3 jours", "vor 3 Tagen", "hace 3 días").
+	AgoPrefixes []string
+}
+
+// LocaleEN is the English locale, the default for parsing and formatting.
+var LocaleEN = &Locale{
+	Name:          "en",
+	Weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	ShortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	Months: [12]string{"January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December"},
+	ShortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	AM:          "AM",
+	PM:          "PM",
+	HumanFormat: "%A, %B %-d, %Y",
+	Words:       map[string]string{"sept": "september"},
+}
+
+// LocaleFR is the French locale.
+var LocaleFR = &Locale{
+	Name:          "fr",
+	Weekdays:      [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+	ShortWeekdays: [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+	Months: [12]string{"janvier", "février", "mars", "avril", "mai", "juin",
+		"juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+	ShortMonths: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin",
+		"juil.", "août", "sept.", "oct.", "nov.", "déc."},
+	AM:          "AM",
+	PM:          "PM",
+	HumanFormat: "%A %-d %B %Y",
+	Words: map[string]string{
+		"aujourd'hui": "today", "maintenant": "now", "demain": "tomorrow", "hier": "yesterday",
+		"apres-demain": "day after tomorrow", "avant-hier": "day before yesterday",
+		"prochain": "next", "prochaine": "next", "dernier": "last", "derniere": "last",
+		"ce": "this", "cette": "this", "dans": "in", "a": "at", "midi": "noon", "minuit": "midnight",
+		"et": "and", "un": "a", "une": "a", "le": "", "la": "",
+		"seconde": "second", "secondes": "seconds", "heure": "hour", "heures": "hours",
+		"jour": "day", "jours": "days", "semaine": "week", "semaines": "weeks",
+		"mois": "months", "trimestre": "quarter", "trimestres": "quarters",
+		"an": "year", "ans": "years", "annee": "year", "annees": "years",
+	},
+	AgoPrefixes: []string{"il y a"},
+}
+
+// LocaleDE is the German locale.
+var LocaleDE = &Locale{
+	Name:          "de",
+	Weekdays:      [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+	ShortWeekdays: [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+	Months: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni",
+		"Juli", "August", "September", "Oktober", "November", "Dezember"},
+	ShortMonths: [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni",
+		"Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+	AM:          "AM",
+	PM:          "PM",
+	HumanFormat: "%A, %-d. %B %Y",
+	Words: map[string]string{
+		"heute": "today", "jetzt": "now", "morgen": "tomorrow", "gestern": "yesterday",
+		"ubermorgen": "day after tomorrow", "vorgestern": "day before yesterday",
+		"nachster": "next", "nachsten": "next", "nachste": "next", "kommenden": "next",
+		"letzter": "last", "letzten": "last", "letzte": "last", "vergangenen": "last",
+		"diese": "this", "diesen": "this", "dieser": "this", "diesem": "this",
+		"in": "in", "um": "at", "mittag": "noon", "mitternacht": "midnight",
+		"und": "and", "ein": "a", "eine": "a", "einem": "a", "einer": "a", "einen": "a", "am": "",
+		"sekunde": "second", "sekunden": "seconds", "minuten": "minutes", "stunde": "hour", "stunden": "hours",
+		"tag": "day", "tage": "days", "tagen": "days", "woche": "week", "wochen": "weeks",
+		"monat": "month", "monate": "months", "monaten": "months", "quartal": "quarter",
+		"jahr": "year", "jahre": "years", "jahren": "years", "uhr": "",
+	},
+	AgoPrefixes: []string{"vor"},
+}
+
+// LocaleES is the Spanish locale.
+var LocaleES = &Locale{
+	Name:          "es",
+	Weekdays:      [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+	ShortWeekdays: [7]string{"dom.", "lun.", "mar.", "mié.", "jue.", "vie.", "sáb."},
+	Months: [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio",
+		"julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+	ShortMonths: [12]string{"ene.", "feb.", "mar.", "abr.", "may.", "jun.",
+		"jul.", "ago.", "sept.", "oct.", "nov.", "dic."},
+	AM:          "a. m.",
+	PM:          "p. m.",
+	HumanFormat: "%A, %-d de %B de %Y",
+	Words: map[string]string{
+		"hoy": "today", "ahora": "now", "manana": "tomorrow", "ayer": "yesterday",
+		"pasado manana": "day after tomorrow", "anteayer": "day before yesterday",
+		"proximo": "next", "proxima": "next", "siguiente": "next", "que viene": "next",
+		"pasado": "last", "pasada": "last", "ultimo": "last", "ultima": "last",
+		"este": "this", "esta": "this", "en": "in", "a las": "at", "a la": "at",
+		"mediodia": "noon", "medianoche": "midnight", "y": "and", "un": "a", "una": "a",
+		"el": "", "la": "", "de": "",
+		"segundo": "second", "segundos": "seconds", "minuto": "minute", "minutos": "minutes",
+		"hora": "hour", "horas": "hours", "dia": "day", "dias": "days",
+		"semana": "week", "semanas": "weeks", "mes": "month", "meses": "months",
+		"trimestre": "quarter", "trimestres": "quarters", "ano": "year", "anos": "years",
+	},
+	AgoPrefixes: []string{"hace"},
+}
+
+// locales lists the built-in locales by language code.
+var locales = map[string]*Locale{
+	"en": LocaleEN,
+	"fr": LocaleFR,
+	"de": LocaleDE,
+	"es": LocaleES,
+}
+
+// LookupLocale returns the built-in locale for a language code such as
+// "fr", "fr_FR", "fr-FR" or "fr_FR.UTF-8". An empty name returns LocaleEN.
+func LookupLocale(name string) (*Locale, error) {
+	if name == "" {
+		return LocaleEN, nil
+	}
+	lang := strings.ToLower(name)
+	if i := strings.IndexAny(lang, "_-."); i >= 0 {
+		lang = lang[:i]
+	}
+	if l, ok := locales[lang]; ok {
+		return l, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownLocale, name)
+}
+
+// WeekdayName returns the localized name of a weekday.
+func (l *Locale) WeekdayName(d time.Weekday, short bool) string {
+	if short {
+		return l.ShortWeekdays[d]
+	}
+	return l.Weekdays[d]
+}
+
+// ParseWeekday parses a weekday name, full or abbreviated, in the locale or
+// in English: "lundi", "Sa.", "sunday", "fri".
+func (l *Locale) ParseWeekday(name string) (time.Weekday, bool) {
+	key := foldName(strings.TrimSpace(name))
+	for _, locale := range []*Locale{l, LocaleEN} {
+		if locale == nil {
+			continue
+		}
+		for i := range locale.Weekdays {
+			if key == foldName(locale.Weekdays[i]) || key == foldName(locale.ShortWeekdays[i]) {
+				return time.Weekday(i), true
+			}
+		}
+	}
+	return time.Sunday, false
+}
+
+// ParseMonth parses a month name, full or abbreviated, in the locale or in
+// English: "avril", "Apr", "april".
+func (l *Locale) ParseMonth(name string) (time.Month, bool) {
+	key := foldName(strings.TrimSpace(name))
+	for _, locale := range []*Locale{l, LocaleEN} {
+		if locale == nil {
+			continue
+		}
+		for i := range locale.Months {
+			if key == foldName(locale.Months[i]) || key == foldName(locale.ShortMonths[i]) {
+				return time.Month(i + 1), true
+			}
+		}
+	}
+	return time.January, false
+}
+
+// MonthName returns the localized name of a month.
+func (l *Locale) MonthName(m time.Month, short bool) string {
+	if short {
+		return l.ShortMonths[m-1]
+	}
+	return l.Months[m-1]
+}
+
+// Translate rewrites localized words of the input into English: keywords
+// ("demain" becomes "tomorrow"), weekday and month names, units and
+// relative adjectives ("lundi prochain" becomes "next monday"). Words it does
+// not know are kept unchanged, so expression syntax passes through.
+func (l *Locale) Translate(input string) string {
+	words := strings.Fields(input)
+	out := []string{}
+	isAgo := false
+
+	for i := 0; i < len(words); {
+		if i == 0 {
+			if n := l.matchAgoPrefix(words); n > 0 {
+				isAgo = true
+				i += n
+				continue
+			}
+		}
+
+		translation, n := l.translateAt(words[i:])
+		if n == 0 {
+			out = append(out, words[i])
+			i++
+			continue
+		}
+		if translation != "" {
+			out = append(out, strings.Fields(translation)...)
+		}
+		i += n
+	}
+
+	out = moveAdjectivesFirst(out)
+	if isAgo {
+		out = append(out, "ago")
+	}
+	return strings.Join(out, " ")
+}
+
+// translateAt translates the longest known phrase at the start of words and
+// returns the translation and the number of words consumed (0 if unknown).
+func (l *Locale) translateAt(words []string) (string, int) {
+	const maxPhraseWords = 3
+
+	for n := min(maxPhraseWords, len(words)); n > 0; n-- {
+		key := foldAccents(strings.ToLower(strings.Join(words[:n], " ")))
+		key = strings.TrimRight(key, ",")
+		if translation, ok := l.Words[key]; ok {
+			return translation, n
+		}
+		if n > 1 {
+			continue
+		}
+		if name, ok := l.lookupName(key); ok {
+			return name, 1
+		}
+	}
+	return "", 0
+}
+
+// lookupName translates a localized weekday or month name (full or abbreviated)
+// into its lower-case English name.
+func (l *Locale) lookupName(key string) (string, bool) {
+	key = strings.TrimSuffix(key, ".")
+	// Full names first, then abbreviations; month abbreviations win over
+	// weekday ones ("mar." is both martes and marzo in Spanish) since they
+	// appear in date literals such as "15 mar. 2024".
+	for i := range l.Weekdays {
+		if key == foldName(l.Weekdays[i]) {
+			return strings.ToLower(LocaleEN.Weekdays[i]), true
+		}
+	}
+	for i := range l.Months {
+		if key == foldName(l.Months[i]) || key == foldName(l.ShortMonths[i]) {
+			return strings.ToLower(LocaleEN.Months[i]), true
+		}
+	}
+	for i := range l.ShortWeekdays {
+		if key == foldName(l.ShortWeekdays[i]) {
+			return strings.ToLower(LocaleEN.Weekdays[i]), true
+		}
+	}
+	return "", false
+}
+
+func (l *Locale) matchAgoPrefix(words []string) int {
+	for _, prefix := range l.AgoPrefixes {
+		prefixWords := strings.Fields(prefix)
+		if len(words) <= len(prefixWords) {
+			continue
+		}
+		if strings.EqualFold(strings.Join(words[:len(prefixWords)], " "), prefix) {
+			return len(prefixWords)
+		}
+	}
+	return 0
+}
+
+// moveAdjectivesFirst turns postposed adjectives into English word order:
+// "monday next" (from "lundi prochain") becomes "next monday".
+func moveAdjectivesFirst(words []string) []string {
+	for i := 1; i < len(words); i++ {
+		if !isRelativeAdjective(words[i]) {
+			continue
+		}
+		prev := words[i-1]
+		if _, isUnit := naturalUnits[prev]; isUnit || isWeekdayName(prev) {
+			words[i-1], words[i] = words[i], prev
+		}
+	}
+	return words
+}
+
+// foldName lower-cases a name, strips its abbreviation dot and removes accents.
+func foldName(name string) string {
+	return foldAccents(strings.TrimSuffix(strings.ToLower(name), "."))
+}
+
+// foldAccents replaces accented Latin letters with their unaccented form so
+// that "fevrier", "février" and "FÉVRIER" match the same table entry.
+func foldAccents(s string) string {
+	return accentReplacer.Replace(s)
+}
+
+var accentReplacer = strings.NewReplacer(
+	"à", "a", "â", "a", "á", "a", "ä", "a",
+	"ç", "c",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"î", "i", "ï", "i", "í", "i",
+	"ô", "o", "ö", "o", "ó", "o",
+	"û", "u", "ù", "u", "ü", "u", "ú", "u",
+	"ñ", "n", "ß", "ss",
+	"É", "e", "Ü", "u", "Ö", "o", "Ä", "a",
+)
@@ -0,0 +1,117 @@
+package calcdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupLocale(t *testing.T) {
+	for _, name := range []string{"", "en", "fr", "fr_FR", "de-DE", "es_ES.UTF-8"} {
+		_, err := LookupLocale(name)
+		require.NoError(t, err, name)
+	}
+
+	_, err := LookupLocale("xx")
+	require.ErrorIs(t, err, ErrUnknownLocale)
+}
+
+func TestLocaleTranslate(t *testing.T) {
+	testCases := []struct {
+		locale   *Locale
+		input    string
+		expected string
+	}{
+		{locale: LocaleFR, input: "demain", expected: "tomorrow"},
+		{locale: LocaleFR, input: "lundi prochain", expected: "next monday"},
+		{locale: LocaleFR, input: "il y a 3 jours", expected: "3 days ago"},
+		{locale: LocaleDE, input: "nächsten Montag", expected: "next monday"},
+		{locale: LocaleES, input: "mañana", expected: "tomorrow"},
+		{locale: LocaleDE, input: "15. Januar 2024", expected: "15. january 2024"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.locale.Name+"/"+tc.input, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.locale.Translate(tc.input))
+		})
+	}
+}
+
+func TestLocalizedParsing(t *testing.T) {
+	parser := NewExprParser("")
+	parser.Natural = true
+	parser.Locale = LocaleFR
+
+	node, err := parser.Parse("lundi 15 janvier 2024")
+	require.NoError(t, err)
+	assert.Equal(t, "2024-01-15", exprString(node))
+}
+
+func TestLocalizedParsingKeepsQuotedArguments(t *testing.T) {
+	testCases := []struct {
+		locale   *Locale
+		input    string
+		expected string
+	}{
+		{locale: LocaleEN, input: `2025-01-01 | nextCron "0 9 1 Jan *"`, expected: "2025-01-01 09:00:00"},
+		{locale: LocaleFR, input: `2025-01-01 | nextCron "0 9 1 Jan *"`, expected: "2025-01-01 09:00:00"},
+		{locale: LocaleES, input: `2025-01-01 | nextCron '0 9 1 mar *'`, expected: "2025-03-01 09:00:00"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.locale.Name+"/"+tc.input, func(t *testing.T) {
+			parser := NewExprParser("")
+			parser.Locale = tc.locale
+			node, err := parser.Parse(tc.input)
+			require.NoError(t, err)
+			result, err := node.Evaluate(&EvalContext{Timezone: time.UTC})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result.Format(time.DateTime))
+		})
+	}
+
+	parser := NewExprParser("")
+	parser.Locale = LocaleFR
+	node, err := parser.Parse(`aujourd'hui | nextCron '0 9 * * *'`)
+	require.NoError(t, err)
+	assert.Equal(t, `today | nextCron "0 9 * * *"`, exprString(node))
+}
+
+func TestFormatterLocalized(t *testing.T) {
+	date := time.Date(2024, 1, 15, 14, 5, 9, 0, time.UTC)
+
+	testCases := []struct {
+		locale   *Locale
+		format   string
+		expected string
+	}{
+		{locale: LocaleFR, format: "", expected: "lundi 15 janvier 2024"},
+		{locale: LocaleDE, format: "", expected: "Montag, 15. Januar 2024"},
+		{locale: LocaleES, format: "", expected: "lunes, 15 de enero de 2024"},
+		{locale: LocaleEN, format: "", expected: "Monday, January 15, 2024"},
+		{locale: LocaleFR, format: "%a %-d %b %H:%M", expected: "lun. 15 janv. 14:05"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.locale.Name+"/"+tc.format, func(t *testing.T) {
+			f := Formatter{Locale: tc.locale}
+			if tc.format == "" {
+				assert.Equal(t, tc.expected, f.Human(date))
+			} else {
+				assert.Equal(t, tc.expected, f.Format(date, tc.format))
+			}
+		})
+	}
+}
+
+func TestFormatterMatchesUnixConversion(t *testing.T) {
+	date := time.Date(2024, 12, 25, 14, 30, 45, 0, time.UTC)
+	formats := []string{"%Y-%m-%d %H:%M:%S", "%A, %B %d, %Y at %I:%M %p %Z", "%y%m%d %a %b"}
+
+	for _, format := range formats {
+		expected := date.Format(ConvertUnixFormatToGolang(format))
+		assert.Equal(t, expected, Formatter{}.Format(date, format), format)
+	}
+}
@@ -0,0 +1,151 @@
+package calcdate
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// logFormat recognizes the timestamp of a log line: re captures it, layout
+// parses it (values without an offset are in the given location).
+type logFormat struct {
+	re    *regexp.Regexp
+	parse func(value string, loc *time.Location, now time.Time) (time.Time, error)
+}
+
+// logFormats are tried in order on every line.
+//
+//nolint:gochecknoglobals // compiled once
+var logFormats = []logFormat{
+	// RFC 3339 and ISO 8601 with a space, comma fractions (Java, Python) and
+	// an optional bracket: "2025-03-01T10:00:00.123Z", "[2025-03-01 10:00:00,123]"
+	{regexp.MustCompile(`^\[?(\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:[.,]\d+)?(?:Z|[+-]\d{2}:?\d{2})?)`), parseISOLogTime},
+	// nginx and Apache access logs: `127.0.0.1 - - [01/Mar/2025:10:00:00 +0100] "GET / HTTP/1.1"`
+	{regexp.MustCompile(`^[^\[]*\[(\d{2}/[A-Z][a-z]{2}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4})\]`), parseCLFLogTime},
+	// syslog (RFC 3164), with an optional priority: "<34>Mar  1 10:00:00 host app: ..."
+	{regexp.MustCompile(`^(?:<\d{1,3}>)?([A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2})`), parseSyslogTime},
+	// Unix epoch in seconds (with an optional fraction) or milliseconds
+	{regexp.MustCompile(`^\[?(\d{13}|\d{10}(?:\.\d+)?)\b`), parseEpochLogTime},
+}
+
+// ParseLogTimestamp finds the timestamp a log line starts with: RFC 3339 or
+// ISO 8601 (optionally in brackets), the date of nginx and Apache access
+// logs, syslog or a Unix epoch in seconds or milliseconds. Timestamps without
+// an offset are in loc; syslog timestamps, which have no year, take the year
+// that puts them at most a day after now.
+func ParseLogTimestamp(line string, loc *time.Location, now time.Time) (time.Time, bool) {
+	for _, format := range logFormats {
+		match := format.re.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		if t, err := format.parse(match[1], loc, now); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func parseISOLogTime(value string, loc *time.Location, _ time.Time) (time.Time, error) {
+	value = strings.Replace(strings.Replace(value, " ", "T", 1), ",", ".", 1)
+	for _, layout := range []string{"2006-01-02T15:04:05.999999999Z07:00", "2006-01-02T15:04:05.999999999Z0700"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.ParseInLocation("2006-01-02T15:04:05.999999999", value, loc) //nolint:wrapcheck // only tells that the line does not match
+}
+
+func parseCLFLogTime(value string, _ *time.Location, _ time.Time) (time.Time, error) {
+	return time.Parse("02/Jan/2006:15:04:05 -0700", value) //nolint:wrapcheck // only tells that the line does not match
+}
+
+func parseSyslogTime(value string, loc *time.Location, now time.Time) (time.Time, error) {
+	t, err := time.ParseInLocation("Jan _2 15:04:05", value, loc)
+	if err != nil {
+		return time.Time{}, err //nolint:wrapcheck // only tells that the line does not match
+	}
+	now = now.In(loc)
+	t = t.AddDate(now.Year()-t.Year(), 0, 0)
+	if t.After(now.AddDate(0, 0, 1)) {
+		t = t.AddDate(-1, 0, 0)
+	}
+	return t, nil
+}
+
+func parseEpochLogTime(value string, loc *time.Location, _ time.Time) (time.Time, error) {
+	const millisDigits = 13
+	if len(value) == millisDigits {
+		ms, err := strconv.ParseInt(value, 10, 64)
+		return time.UnixMilli(ms).In(loc), err //nolint:wrapcheck // only tells that the line does not match
+	}
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return time.Time{}, err //nolint:wrapcheck // only tells that the line does not match
+	}
+	return time.UnixMicro(int64(seconds * float64(time.Second/time.Microsecond))).In(loc), nil
+}
+
+// SeekLogTime returns the offset of a line of a log sorted by time, with
+// timestamps read by ParseLogTimestamp, from which every line at or after
+// start follows: a binary search reading a few lines at each step instead of
+// the whole log. Lines without a timestamp belong to the last line with one.
+func SeekLogTime(log io.ReaderAt, size int64, start time.Time, loc *time.Location, now time.Time) (int64, error) {
+	// Below this many bytes, reading the lines is cheaper than seeking.
+	const scanBytes = 4096
+
+	lo, hi := int64(0), size
+	for hi-lo > scanBytes {
+		mid := lo + (hi-lo)/2
+		t, found, err := firstLogTimeAfter(log, mid, size, loc, now)
+		if err != nil {
+			return 0, err
+		}
+		if found && t.Before(start) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lineStartAfter(log, lo, size)
+}
+
+// lineStartAfter returns the offset of the first line starting at or after
+// offset.
+func lineStartAfter(log io.ReaderAt, offset, size int64) (int64, error) {
+	if offset == 0 {
+		return 0, nil
+	}
+	reader := bufio.NewReader(io.NewSectionReader(log, offset-1, size-offset+1))
+	skipped, err := reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, err //nolint:wrapcheck // read errors are reported as is
+	}
+	return offset - 1 + int64(len(skipped)), nil
+}
+
+// firstLogTimeAfter returns the timestamp of the first line with one
+// starting at or after offset.
+func firstLogTimeAfter(log io.ReaderAt, offset, size int64, loc *time.Location, now time.Time) (time.Time, bool, error) {
+	start, err := lineStartAfter(log, offset, size)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	reader := bufio.NewReader(io.NewSectionReader(log, start, size-start))
+	for {
+		line, err := reader.ReadString('\n')
+		if t, ok := ParseLogTimestamp(line, loc, now); ok {
+			return t, true, nil
+		}
+		if errors.Is(err, io.EOF) {
+			return time.Time{}, false, nil
+		}
+		if err != nil {
+			return time.Time{}, false, err //nolint:wrapcheck // read errors are reported as is
+		}
+	}
+}
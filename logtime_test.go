@@ -0,0 +1,69 @@
+package calcdate
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogTimestamp(t *testing.T) {
+	paris := mustLoad(t, "Europe/Paris")
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, paris)
+	tests := []struct {
+		line, want string
+	}{
+		{"2025-03-01T10:00:00Z INFO started", "2025-03-01T11:00:00+01:00"},
+		{"2025-03-01T10:00:00.250+05:30 INFO", "2025-03-01T05:30:00.25+01:00"},
+		{"[2025-03-01 10:00:00,123] WARN slow", "2025-03-01T10:00:00.123+01:00"},
+		{"2025-03-01 10:00:00 +0000 msg", "2025-03-01T10:00:00+01:00"},
+		{`127.0.0.1 - - [01/Mar/2025:10:00:00 +0000] "GET / HTTP/1.1" 200`, "2025-03-01T11:00:00+01:00"},
+		{"<34>Mar  1 10:00:00 host sshd[42]: accepted", "2025-03-01T10:00:00+01:00"},
+		{"Dec 31 23:00:00 host cron: last year", "2024-12-31T23:00:00+01:00"},
+		{"1740823200 event", "2025-03-01T11:00:00+01:00"},
+		{"1740823200.5 event", "2025-03-01T11:00:00.5+01:00"},
+		{"1740823200500 event", "2025-03-01T11:00:00.5+01:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, ok := ParseLogTimestamp(tt.line, paris, now)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, got.In(paris).Format(time.RFC3339Nano))
+		})
+	}
+
+	for _, line := range []string{"", "  at main.go:12", "INFO 2025-03-01T10:00:00Z", "17408232001234567 id"} {
+		_, ok := ParseLogTimestamp(line, paris, now)
+		assert.False(t, ok, line)
+	}
+}
+
+func TestSeekLogTime(t *testing.T) {
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	var log strings.Builder
+	offsets := []int64{}
+	for i := range 5000 {
+		offsets = append(offsets, int64(log.Len()))
+		fmt.Fprintf(&log, "%s request %d\n", start.Add(time.Duration(i)*time.Minute).Format(time.RFC3339), i)
+		if i%10 == 0 {
+			log.WriteString("  at stack.trace\n")
+		}
+	}
+	reader := strings.NewReader(log.String())
+	size := int64(log.Len())
+
+	for _, i := range []int{0, 1, 10, 11, 2500, 4999} {
+		offset, err := SeekLogTime(reader, size, start.Add(time.Duration(i)*time.Minute), time.UTC, start)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, offset, offsets[i])
+		assert.Greater(t, offset+4096, offsets[i])
+		assert.True(t, offset == 0 || log.String()[offset-1] == '\n')
+	}
+
+	offset, err := SeekLogTime(reader, size, start.AddDate(1, 0, 0), time.UTC, start)
+	require.NoError(t, err)
+	assert.Greater(t, offset+4096, size)
+}
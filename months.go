@@ -0,0 +1,60 @@
+package calcdate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MonthOverflowPolicy selects what adding months, quarters or years does
+// when the day does not exist in the target month, e.g. "2024-01-31 +1M".
+// The zero value is MonthOverflow.
+type MonthOverflowPolicy string
+
+// Month overflow policies.
+const (
+	// MonthClamp stops at the last day of the target month (2024-02-29).
+	MonthClamp MonthOverflowPolicy = "clamp"
+	// MonthOverflow carries the extra days into the next month (2024-03-02),
+	// like time.AddDate.
+	MonthOverflow MonthOverflowPolicy = "overflow"
+	// MonthOverflowError rejects the addition.
+	MonthOverflowError MonthOverflowPolicy = "error"
+)
+
+// MonthOverflowPolicies lists the policies accepted by ParseMonthOverflowPolicy.
+var MonthOverflowPolicies = []MonthOverflowPolicy{MonthClamp, MonthOverflow, MonthOverflowError}
+
+// ParseMonthOverflowPolicy parses a policy name; "" is MonthOverflow.
+func ParseMonthOverflowPolicy(name string) (MonthOverflowPolicy, error) {
+	if name == "" {
+		return MonthOverflow, nil
+	}
+	for _, policy := range MonthOverflowPolicies {
+		if strings.EqualFold(name, string(policy)) {
+			return policy, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownMonthOverflowPolicy, name)
+}
+
+// AddMonths adds months to t, keeping its clock, and handles days missing
+// from the target month according to policy.
+func AddMonths(t time.Time, months int, policy MonthOverflowPolicy) (time.Time, error) {
+	target := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := DayInMonth(target.Year(), int(target.Month()))
+	if t.Day() <= lastDay {
+		return t.AddDate(0, months, 0), nil
+	}
+
+	switch policy {
+	case MonthClamp:
+		return time.Date(target.Year(), target.Month(), lastDay,
+			t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()), nil
+	case MonthOverflowError:
+		return time.Time{}, fmt.Errorf("%w: %s %+dM, %s %d has %d days", ErrMonthOverflow,
+			t.Format(time.DateOnly), months, target.Month(), target.Year(), lastDay)
+	default:
+		return t.AddDate(0, months, 0), nil
+	}
+}
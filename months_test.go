@@ -0,0 +1,111 @@
+package calcdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddMonths(t *testing.T) {
+	jan31 := time.Date(2024, 1, 31, 10, 30, 0, 0, time.UTC)
+	leapDay := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		date     time.Time
+		months   int
+		policy   MonthOverflowPolicy
+		expected string
+	}{
+		{"clamp", jan31, 1, MonthClamp, "2024-02-29 10:30:00"},
+		{"overflow", jan31, 1, MonthOverflow, "2024-03-02 10:30:00"},
+		{"default", jan31, 1, "", "2024-03-02 10:30:00"},
+		{"clamp backwards", time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), -1, MonthClamp, "2024-02-29 00:00:00"},
+		{"clamp year", leapDay, MonthsInYear, MonthClamp, "2025-02-28 00:00:00"},
+		{"clamp across year", time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), 2, MonthClamp, "2025-02-28 00:00:00"},
+		{"existing day", jan31, 2, MonthOverflowError, "2024-03-31 10:30:00"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := AddMonths(tc.date, tc.months, tc.policy)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result.Format(time.DateTime))
+		})
+	}
+
+	_, err := AddMonths(jan31, 1, MonthOverflowError)
+	require.ErrorIs(t, err, ErrMonthOverflow)
+}
+
+func TestParseMonthOverflowPolicy(t *testing.T) {
+	policy, err := ParseMonthOverflowPolicy("Clamp")
+	require.NoError(t, err)
+	assert.Equal(t, MonthClamp, policy)
+
+	policy, err = ParseMonthOverflowPolicy("")
+	require.NoError(t, err)
+	assert.Equal(t, MonthOverflow, policy)
+
+	_, err = ParseMonthOverflowPolicy("round")
+	require.ErrorIs(t, err, ErrUnknownMonthOverflowPolicy)
+}
+
+func TestEvaluateWithMonthOverflow(t *testing.T) {
+	tests := []struct {
+		expr     string
+		policy   MonthOverflowPolicy
+		expected string
+	}{
+		{"2024-01-31 | +1M", MonthClamp, "2024-02-29"},
+		{"2024-01-31 | +1M", MonthOverflow, "2024-03-02"},
+		{"2024-05-31 | -1q", MonthClamp, "2024-02-29"},
+		{"2024-02-29 | +1Y", MonthClamp, "2025-02-28"},
+		{"2024-01-31 | +1M1d", MonthClamp, "2024-03-01"},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.policy)+" "+tc.expr, func(t *testing.T) {
+			node, err := NewExprParser("").Parse(tc.expr)
+			require.NoError(t, err)
+			result, err := node.Evaluate(&EvalContext{Timezone: time.UTC, MonthOverflow: tc.policy})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result.Format(time.DateOnly))
+		})
+	}
+
+	node, err := NewExprParser("").Parse("2024-01-31 | +1M")
+	require.NoError(t, err)
+	_, err = node.Evaluate(&EvalContext{Timezone: time.UTC, MonthOverflow: MonthOverflowError})
+	require.ErrorIs(t, err, ErrMonthOverflow)
+}
+
+func TestIterateMonthsAnchored(t *testing.T) {
+	start := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	results, err := IterateWithSpecialIntervalContext(start, end, "1M", nil,
+		&EvalContext{Timezone: time.UTC, MonthOverflow: MonthClamp})
+	require.NoError(t, err)
+
+	ends := []string{}
+	for _, r := range results {
+		ends = append(ends, r.EndTime.Format(time.DateOnly))
+	}
+	assert.Equal(t, []string{"2024-02-29", "2024-03-31", "2024-04-30", "2024-05-31", "2024-06-30"}, ends)
+
+	// The default policy steps from the end of the previous iteration
+	results, err = IterateWithSpecialIntervalContext(start, end, "1M", nil, &EvalContext{Timezone: time.UTC})
+	require.NoError(t, err)
+	ends = []string{}
+	for _, r := range results {
+		ends = append(ends, r.EndTime.Format(time.DateOnly))
+	}
+	assert.Equal(t, []string{"2024-03-02", "2024-04-02", "2024-05-02", "2024-06-02", "2024-06-30"}, ends)
+
+	_, err = IterateWithSpecialIntervalContext(start, end, "1M", nil,
+		&EvalContext{Timezone: time.UTC, MonthOverflow: MonthOverflowError})
+	require.ErrorIs(t, err, ErrMonthOverflow)
+}
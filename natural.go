@@ -0,0 +1,420 @@
+package calcdate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// naturalNumbers maps spelled-out numbers to their value.
+var naturalNumbers = map[string]float64{
+	"a": 1, "an": 1, "zero": 0, "one": 1, "two": 2, "three": 3, "four": 4,
+	"five": 5, "six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+	"eleven": 11, "twelve": 12, "thirteen": 13, "fourteen": 14, "fifteen": 15,
+	"sixteen": 16, "seventeen": 17, "eighteen": 18, "nineteen": 19,
+}
+
+// naturalTens maps spelled-out tens, which may be followed by a unit number ("twenty five").
+var naturalTens = map[string]float64{
+	"twenty": 20, "thirty": 30, "forty": 40, "fifty": 50,
+	"sixty": 60, "seventy": 70, "eighty": 80, "ninety": 90,
+}
+
+// naturalUnit is a spelled-out unit and its equivalent in the expression syntax.
+type naturalUnit struct {
+	unit       string
+	multiplier float64
+	boundary   string // unit name used by startOf*/endOf* operations, if any
+}
+
+// naturalUnits maps spelled-out units (singular and plural) to expression units.
+var naturalUnits = map[string]naturalUnit{
+	"second": {"s", 1, "second"}, "seconds": {"s", 1, "second"}, "sec": {"s", 1, "second"}, "secs": {"s", 1, "second"},
+	"minute": {"m", 1, "minute"}, "minutes": {"m", 1, "minute"}, "min": {"m", 1, "minute"}, "mins": {"m", 1, "minute"},
+	"hour": {"h", 1, "hour"}, "hours": {"h", 1, "hour"}, "hr": {"h", 1, "hour"}, "hrs": {"h", 1, "hour"},
+	"day": {"d", 1, "day"}, "days": {"d", 1, "day"},
+	"week": {"w", 1, "week"}, "weeks": {"w", 1, "week"},
+	"fortnight": {"w", 2, ""}, "fortnights": {"w", 2, ""},
+	"month": {"M", 1, "month"}, "months": {"M", 1, "month"},
+	"quarter": {"q", 1, "quarter"}, "quarters": {"q", 1, "quarter"},
+	"year": {"Y", 1, "year"}, "years": {"Y", 1, "year"},
+}
+
+// naturalDates are the single words accepted as the anchor of a phrase.
+var naturalDates = map[string]bool{
+	"now": true, "today": true, "tomorrow": true, "yesterday": true,
+	"monday": true, "tuesday": true, "wednesday": true, "thursday": true,
+	"friday": true, "saturday": true, "sunday": true,
+}
+
+// ParseNatural rewrites an English phrase such as "3 days ago", "in 2 weeks",
+// "next monday at 9am" or "tomorrow noon" into an expression tree built from
+// the regular DateNode, PipeNode and OperationNode types.
+// It returns ErrNotNaturalLanguage when the input is not a phrase it understands,
+// so callers can fall back to the regular expression syntax.
+//
+//nolint:ireturn // returns interface by design for AST nodes
+func ParseNatural(input string) (ExprNode, error) {
+	words := naturalWords(input)
+	if len(words) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotNaturalLanguage, input)
+	}
+
+	words, timeOp, ok := extractTimeOfDay(words)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotNaturalLanguage, input)
+	}
+
+	base, ops, ok := parseNaturalDate(words, timeOp != nil)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotNaturalLanguage, input)
+	}
+	if timeOp != nil {
+		ops = append(ops, timeOp)
+	}
+
+	if len(ops) == 0 {
+		return base, nil
+	}
+	return &PipeNode{Base: base, Operations: ops}, nil
+}
+
+// naturalWords lower-cases the input and splits it into words, dropping commas
+// and splitting hyphenated numbers such as "twenty-five".
+func naturalWords(input string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(input), func(r rune) bool {
+		return unicode.IsSpace(r) || r == ','
+	})
+
+	words := []string{}
+	for _, field := range fields {
+		if strings.Contains(field, "-") && strings.IndexFunc(field, unicode.IsDigit) == -1 {
+			words = append(words, strings.Split(field, "-")...)
+			continue
+		}
+		words = append(words, field)
+	}
+	return words
+}
+
+// extractTimeOfDay removes a time-of-day clause ("at 9am", "noon", "17:30")
+// from the words and returns it as a time operation.
+func extractTimeOfDay(words []string) ([]string, *OperationNode, bool) {
+	for i := 0; i < len(words); i++ {
+		start := i
+		explicit := words[i] == "at"
+		if explicit {
+			i++
+			if i >= len(words) {
+				return nil, nil, false
+			}
+		}
+
+		value, n, ok := parseTimeOfDay(words[i:], explicit)
+		if !ok {
+			if explicit {
+				return nil, nil, false
+			}
+			continue
+		}
+
+		rest := append(append([]string{}, words[:start]...), words[i+n:]...)
+		return rest, &OperationNode{Op: "time", Value: value}, true
+	}
+	return words, nil, true
+}
+
+// parseTimeOfDay parses "noon", "midnight", "5pm", "5:30 pm" or "17:30".
+// Bare hours ("at 17") are only accepted after "at".
+func parseTimeOfDay(words []string, explicit bool) (string, int, bool) {
+	const hoursPerHalfDay = 12
+
+	word := words[0]
+	switch word {
+	case "noon", "midday":
+		return "12:00", 1, true
+	case "midnight":
+		return "00:00", 1, true
+	}
+
+	n := 1
+	meridiem := ""
+	switch {
+	case strings.HasSuffix(word, "am") || strings.HasSuffix(word, "pm"):
+		meridiem = word[len(word)-2:]
+		word = word[:len(word)-2]
+	case len(words) > 1 && (words[1] == "am" || words[1] == "pm"):
+		meridiem = words[1]
+		n = 2
+	case !explicit && !strings.Contains(word, ":"):
+		return "", 0, false
+	}
+
+	hour, minute, ok := splitClock(word)
+	if !ok {
+		return "", 0, false
+	}
+
+	if meridiem != "" {
+		if hour < 1 || hour > hoursPerHalfDay {
+			return "", 0, false
+		}
+		hour %= hoursPerHalfDay
+		if meridiem == "pm" {
+			hour += hoursPerHalfDay
+		}
+	}
+	return fmt.Sprintf("%02d:%02d", hour, minute), n, true
+}
+
+// splitClock parses "H", "HH" or "H:MM" into hour and minute.
+func splitClock(value string) (int, int, bool) {
+	hourStr, minuteStr, hasMinute := strings.Cut(value, ":")
+	hour, err := strconv.Atoi(hourStr)
+	if err != nil || hour < 0 || hour >= HoursInDay {
+		return 0, 0, false
+	}
+	if !hasMinute {
+		return hour, 0, true
+	}
+	minute, err := strconv.Atoi(minuteStr)
+	if err != nil || len(minuteStr) != 2 || minute < 0 || minute >= MinutesInHour {
+		return 0, 0, false
+	}
+	return hour, minute, true
+}
+
+// parseNaturalDate parses the date part of a phrase (without its time of day).
+//
+//nolint:cyclop // one branch per supported phrase shape
+func parseNaturalDate(words []string, hasTime bool) (ExprNode, []ExprNode, bool) {
+	if len(words) > 0 && words[0] == "the" {
+		words = words[1:]
+	}
+
+	switch {
+	case len(words) == 0:
+		// A bare time of day ("noon", "at 5pm") applies to today
+		return &DateNode{Value: "today"}, nil, hasTime
+	case len(words) == 1 && naturalDates[words[0]]:
+		return &DateNode{Value: words[0]}, nil, true
+	case equalWords(words, "day", "after", "tomorrow"):
+		return &DateNode{Value: "tomorrow"}, []ExprNode{&OperationNode{Op: "+", Value: "1d"}}, true
+	case equalWords(words, "day", "before", "yesterday"):
+		return &DateNode{Value: "yesterday"}, []ExprNode{&OperationNode{Op: "-", Value: "1d"}}, true
+	case len(words) == 2 && isRelativeAdjective(words[0]):
+		return parseRelativeAdjective(words[0], words[1])
+	case words[0] == "in":
+		offset, ok := parseQuantities(words[1:])
+		return &DateNode{Value: "now"}, []ExprNode{&OperationNode{Op: "+", Value: offset}}, ok
+	case words[len(words)-1] == "ago":
+		offset, ok := parseQuantities(words[:len(words)-1])
+		return &DateNode{Value: "now"}, []ExprNode{&OperationNode{Op: "-", Value: offset}}, ok
+	case len(words) >= 3 && naturalDates[words[len(words)-1]]:
+		return parseAnchoredOffset(words)
+	default:
+		date, ok := parseCalendarDate(words)
+		return date, nil, ok
+	}
+}
+
+// parseCalendarDate parses dates written with a month name, optionally
+// preceded by a weekday: "15 january 2024", "jan 15 2024", "monday 15th jan 2024".
+func parseCalendarDate(words []string) (ExprNode, bool) {
+	const calendarDateWords = 3
+
+	if len(words) == calendarDateWords+1 && isWeekdayName(words[0]) {
+		words = words[1:]
+	}
+	if len(words) != calendarDateWords {
+		return nil, false
+	}
+
+	month, ok := parseMonthName(words[0])
+	dayWord := words[1]
+	if !ok {
+		month, ok = parseMonthName(words[1])
+		dayWord = words[0]
+	}
+	if !ok {
+		return nil, false
+	}
+
+	day, err := strconv.Atoi(trimOrdinalSuffix(dayWord))
+	if err != nil || day < 1 || day > DayInMonth(2000, int(month)) {
+		return nil, false
+	}
+	year, err := strconv.Atoi(words[2])
+	if err != nil || len(words[2]) != 4 {
+		return nil, false
+	}
+
+	return &DateNode{Value: fmt.Sprintf("%04d-%02d-%02d", year, int(month), day)}, true
+}
+
+// parseMonthName parses an English month name or its abbreviation.
+func parseMonthName(word string) (time.Month, bool) {
+	const abbreviationLength = 3
+
+	word = strings.TrimSuffix(word, ".")
+	for i, name := range LocaleEN.Months {
+		name = strings.ToLower(name)
+		if word == name || (len(word) >= abbreviationLength && strings.HasPrefix(name, word)) {
+			return time.Month(i + 1), true
+		}
+	}
+	return 0, false
+}
+
+// trimOrdinalSuffix removes English, French and German ordinal suffixes ("1st", "1er", "1.").
+func trimOrdinalSuffix(word string) string {
+	for _, suffix := range []string{"st", "nd", "rd", "th", "er", "."} {
+		if trimmed, ok := strings.CutSuffix(word, suffix); ok {
+			return trimmed
+		}
+	}
+	return word
+}
+
+func isRelativeAdjective(word string) bool {
+	return word == "next" || word == "last" || word == "previous" || word == "this"
+}
+
+// parseRelativeAdjective handles "next monday", "last week", "this month"...
+func parseRelativeAdjective(adjective, noun string) (ExprNode, []ExprNode, bool) {
+	if isWeekdayName(noun) {
+		today := &DateNode{Value: "today"}
+		switch adjective {
+		case "next":
+			return today, []ExprNode{&OperationNode{Op: "next", Value: noun}}, true
+		case "this":
+			// The day before the start of the week is the last day of the previous
+			// week, so the next occurrence from there falls within the current week
+			return today, []ExprNode{
+				&OperationNode{Op: "startofweek"},
+				&OperationNode{Op: "-", Value: "1d"},
+				&OperationNode{Op: "next", Value: noun},
+			}, true
+		default:
+			return today, []ExprNode{&OperationNode{Op: "prev", Value: noun}}, true
+		}
+	}
+
+	unit, ok := naturalUnits[noun]
+	if !ok {
+		return nil, nil, false
+	}
+
+	switch adjective {
+	case "next":
+		return &DateNode{Value: "now"}, []ExprNode{&OperationNode{Op: "+", Value: formatQuantity(1, unit)}}, true
+	case "this":
+		if unit.boundary == "" {
+			return nil, nil, false
+		}
+		base := "today"
+		if unit.unit == "h" || unit.unit == "m" || unit.unit == "s" {
+			base = "now"
+		}
+		return &DateNode{Value: base}, []ExprNode{&OperationNode{Op: "startof" + unit.boundary}}, true
+	default:
+		return &DateNode{Value: "now"}, []ExprNode{&OperationNode{Op: "-", Value: formatQuantity(1, unit)}}, true
+	}
+}
+
+// parseAnchoredOffset handles "2 weeks from now", "3 days after tomorrow"
+// and "1 day before friday".
+func parseAnchoredOffset(words []string) (ExprNode, []ExprNode, bool) {
+	anchor := words[len(words)-1]
+	op := ""
+	switch words[len(words)-2] {
+	case "from", "after":
+		op = "+"
+	case "before":
+		op = "-"
+	default:
+		return nil, nil, false
+	}
+
+	offset, ok := parseQuantities(words[:len(words)-2])
+	return &DateNode{Value: anchor}, []ExprNode{&OperationNode{Op: op, Value: offset}}, ok
+}
+
+// parseQuantities parses "3 days", "an hour and 30 minutes" or "two weeks, 3 days"
+// into a compound offset such as "1h30m".
+func parseQuantities(words []string) (string, bool) {
+	offset := ""
+	for len(words) > 0 {
+		num, n, ok := parseNaturalNumber(words)
+		if !ok || n >= len(words) {
+			return "", false
+		}
+		unit, ok := naturalUnits[words[n]]
+		if !ok {
+			return "", false
+		}
+		offset += formatQuantity(num, unit)
+
+		words = words[n+1:]
+		if len(words) > 0 && words[0] == "and" {
+			words = words[1:]
+			if len(words) == 0 {
+				return "", false
+			}
+		}
+	}
+	return offset, offset != ""
+}
+
+// parseNaturalNumber parses a number written with digits or spelled out
+// ("3", "1.5", "a", "twenty five", "a couple of") and returns the number of words consumed.
+func parseNaturalNumber(words []string) (float64, int, bool) {
+	const couple = 2
+
+	if num, err := strconv.ParseFloat(words[0], 64); err == nil && num >= 0 {
+		return num, 1, true
+	}
+
+	if words[0] == "couple" || (len(words) > 1 && words[0] == "a" && words[1] == "couple") {
+		n := 1
+		if words[0] == "a" {
+			n = 2
+		}
+		if n < len(words) && words[n] == "of" {
+			n++
+		}
+		return couple, n, true
+	}
+
+	if tens, ok := naturalTens[words[0]]; ok {
+		if len(words) > 1 {
+			if ones, ok := naturalNumbers[words[1]]; ok && ones >= 1 && ones <= 9 && words[1] != "a" && words[1] != "an" {
+				return tens + ones, 2, true
+			}
+		}
+		return tens, 1, true
+	}
+
+	if num, ok := naturalNumbers[words[0]]; ok {
+		return num, 1, true
+	}
+	return 0, 0, false
+}
+
+func formatQuantity(num float64, unit naturalUnit) string {
+	return strconv.FormatFloat(num*unit.multiplier, 'f', -1, 64) + unit.unit
+}
+
+func equalWords(words []string, expected ...string) bool {
+	if len(words) != len(expected) {
+		return false
+	}
+	for i := range words {
+		if words[i] != expected[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package calcdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNatural(t *testing.T) {
+	testCases := []struct {
+		input     string
+		canonical string
+	}{
+		{input: "3 days ago", canonical: "now | -3d"},
+		{input: "in 2 weeks", canonical: "now | +2w"},
+		{input: "In Two Weeks", canonical: "now | +2w"},
+		{input: "an hour and 30 minutes ago", canonical: "now | -1h30m"},
+		{input: "twenty-five minutes from now", canonical: "now | +25m"},
+		{input: "a couple of days ago", canonical: "now | -2d"},
+		{input: "next monday", canonical: "today | next monday"},
+		{input: "last friday at 5pm", canonical: "today | prev friday | time 17:00"},
+		{input: "this sunday", canonical: "today | startOfWeek | -1d | next sunday"},
+		{input: "next month", canonical: "now | +1M"},
+		{input: "last year", canonical: "now | -1Y"},
+		{input: "this week", canonical: "today | startOfWeek"},
+		{input: "this hour", canonical: "now | startOfHour"},
+		{input: "tomorrow noon", canonical: "tomorrow | time 12:00"},
+		{input: "noon", canonical: "today | time 12:00"},
+		{input: "yesterday at midnight", canonical: "yesterday | time 00:00"},
+		{input: "the day after tomorrow", canonical: "tomorrow | +1d"},
+		{input: "2 days before friday", canonical: "friday | -2d"},
+		{input: "in 1.5 hours", canonical: "now | +1.5h"},
+		{input: "tomorrow", canonical: "tomorrow"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			node, err := ParseNatural(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.canonical, exprString(node))
+		})
+	}
+}
+
+func TestParseNaturalRejectsExpressions(t *testing.T) {
+	for _, input := range []string{"today +1d", "now | round hour", "today...+7d", "3 days", "in", "at", "13pm"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseNatural(input)
+			require.ErrorIs(t, err, ErrNotNaturalLanguage)
+		})
+	}
+}
+
+func TestNaturalEvaluation(t *testing.T) {
+	// Wednesday 2024-01-17 10:30:00 UTC
+	ctx := &EvalContext{Now: time.Date(2024, 1, 17, 10, 30, 0, 0, time.UTC), Timezone: time.UTC}
+
+	testCases := []struct {
+		input    string
+		expected time.Time
+	}{
+		{input: "3 days ago", expected: time.Date(2024, 1, 14, 10, 30, 0, 0, time.UTC)},
+		{input: "next monday at 9am", expected: time.Date(2024, 1, 22, 9, 0, 0, 0, time.UTC)},
+		{input: "last wednesday", expected: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)},
+		{input: "this monday", expected: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{input: "this sunday", expected: time.Date(2024, 1, 21, 0, 0, 0, 0, time.UTC)},
+	}
+
+	parser := NewExprParser("")
+	parser.Natural = true
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			node, err := parser.Parse(tc.input)
+			require.NoError(t, err)
+			result, err := node.Evaluate(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+
+			// The canonical expression evaluates to the same date
+			canonical, err := NewExprParser("").Parse(exprString(node))
+			require.NoError(t, err)
+			result, err = canonical.Evaluate(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
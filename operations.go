@@ -1,7 +1,10 @@
 package calcdate
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -29,13 +32,17 @@ func ParseDateValue(value string, ctx *EvalContext) (time.Time, error) {
 	
 	// Check for relative dates like "+1d", "-2w"
 	if strings.HasPrefix(value, "+") || strings.HasPrefix(value, "-") {
-		return applyRelativeDate(now, value, loc)
+		return applyRelativeOffset(now, value, ctx.MonthOverflow)
 	}
 	
 	// Try to parse as ISO date
-	if t, err := parseISODate(value, loc); err == nil {
+	t, err := parseISODate(value, loc, ctx.AbbrevPolicy)
+	if err == nil {
 		return t, nil
 	}
+	if errors.Is(err, ErrAmbiguousTimezoneAbbrev) {
+		return time.Time{}, err
+	}
 	
 	// Try to parse as time (HH:MM:SS)
 	if t, err := parseTimeOnly(value, now, loc); err == nil {
@@ -99,18 +106,52 @@ func parseWeekday(name string) time.Weekday {
 	}
 }
 
+// relativeComponent is a single number/unit pair of a relative offset,
+// e.g. the "12h" in "+1d12h".
+type relativeComponent struct {
+	value float64
+	unit  string
+}
+
+// relativeUnitOrder lists the offset units from the largest to the smallest.
+// Compound offsets are applied in this order.
+const relativeUnitOrder = "YqMwdhms"
+
+// applyRelativeDate applies an offset such as "+1d12h" with the default
+// month overflow policy.
 func applyRelativeDate(base time.Time, value string, _ *time.Location) (time.Time, error) {
+	return applyRelativeOffset(base, value, MonthOverflow)
+}
+
+// applyRelativeOffset applies an offset such as "+1M15d"; months, quarters
+// and years follow the month overflow policy.
+func applyRelativeOffset(base time.Time, value string, months MonthOverflowPolicy) (time.Time, error) {
 	if len(value) < MinIntervalLength {
 		return time.Time{}, fmt.Errorf("%w: %s", ErrInvalidDateValue, value)
 	}
 	
 	sign, startIdx := parseSign(value)
-	num, unit, err := parseRelativeComponents(value, startIdx)
+	components, err := parseRelativeComponents(value, startIdx)
 	if err != nil {
 		return time.Time{}, err
 	}
 	
-	return applyRelativeDelta(base, sign*num, unit)
+	return addRelativeComponents(base, components, float64(sign), months)
+}
+
+// addRelativeComponents adds the components, each multiplied by factor.
+func addRelativeComponents(
+	base time.Time, components []relativeComponent, factor float64, months MonthOverflowPolicy,
+) (time.Time, error) {
+	result := base
+	for _, c := range components {
+		var err error
+		result, err = applyRelativeDelta(result, factor*c.value, c.unit, months)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+	return result, nil
 }
 
 func parseSign(value string) (int, int) {
@@ -124,50 +165,106 @@ func parseSign(value string) (int, int) {
 	}
 }
 
-func parseRelativeComponents(value string, startIdx int) (int, string, error) {
-	// Find where the number ends and unit begins
-	unitIdx := startIdx
-	for unitIdx < len(value) && (value[unitIdx] >= '0' && value[unitIdx] <= '9') {
-		unitIdx++
-	}
+// parseRelativeComponents splits an offset such as "1d12h30m" or "1.5h" into
+// its number/unit pairs, sorted in calendar order (years first, seconds last).
+func parseRelativeComponents(value string, startIdx int) ([]relativeComponent, error) {
+	components := []relativeComponent{}
+	idx := startIdx
 	
-	if unitIdx == startIdx || unitIdx >= len(value) {
-		return 0, "", fmt.Errorf("%w: %s", ErrInvalidDateValue, value)
+	for idx < len(value) {
+		// Find where the number ends and unit begins
+		unitIdx := idx
+		for unitIdx < len(value) && (isDigit(value[unitIdx]) || value[unitIdx] == '.') {
+			unitIdx++
+		}
+		
+		if unitIdx == idx || unitIdx >= len(value) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidDateValue, value)
+		}
+		
+		// Parse number
+		num, err := strconv.ParseFloat(value[idx:unitIdx], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidNumberFormat, value)
+		}
+		
+		// The unit runs until the next number
+		idx = unitIdx
+		for idx < len(value) && !isDigit(value[idx]) {
+			idx++
+		}
+		
+		components = append(components, relativeComponent{value: num, unit: value[unitIdx:idx]})
 	}
 	
-	// Parse number
-	num, err := strconv.Atoi(value[startIdx:unitIdx])
-	if err != nil {
-		return 0, "", fmt.Errorf("%w: %s", ErrInvalidNumberFormat, value)
+	if len(components) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidDateValue, value)
 	}
 	
-	return num, value[unitIdx:], nil
+	sort.SliceStable(components, func(i, j int) bool {
+		return strings.Index(relativeUnitOrder, components[i].unit) <
+			strings.Index(relativeUnitOrder, components[j].unit)
+	})
+	
+	return components, nil
 }
 
-func applyRelativeDelta(base time.Time, num int, unit string) (time.Time, error) {
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func applyRelativeDelta(base time.Time, num float64, unit string, policy MonthOverflowPolicy) (time.Time, error) {
 	switch unit {
 	case "s":
-		return base.Add(time.Duration(num) * time.Second), nil
+		return base.Add(scaleDuration(num, time.Second)), nil
 	case "m":
-		return base.Add(time.Duration(num) * time.Minute), nil
+		return base.Add(scaleDuration(num, time.Minute)), nil
 	case "h":
-		return base.Add(time.Duration(num) * time.Hour), nil
+		return base.Add(scaleDuration(num, time.Hour)), nil
 	case "d":
-		return base.AddDate(0, 0, num), nil
+		return addFractionalDays(base, num), nil
 	case "w":
-		return base.AddDate(0, 0, num*DaysInWeek), nil
-	case "M":
-		return base.AddDate(0, num, 0), nil
-	case "Y":
-		return base.AddDate(num, 0, 0), nil
-	case "q":
-		return base.AddDate(0, num*MonthsInQuarter, 0), nil
+		return addFractionalDays(base, num*DaysInWeek), nil
+	case "M", "q", "Y":
+		months, err := wholeMonths(num, unit)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return AddMonths(base, months, policy)
 	default:
 		return time.Time{}, fmt.Errorf("%w: %s", ErrUnknownUnit, unit)
 	}
 }
 
-func parseISODate(value string, loc *time.Location) (time.Time, error) {
+// scaleDuration multiplies a unit duration by a possibly fractional amount.
+func scaleDuration(num float64, unit time.Duration) time.Duration {
+	return time.Duration(math.Round(num * float64(unit)))
+}
+
+// addFractionalDays adds whole days as calendar days and the remaining
+// fraction of a day as a fixed duration.
+func addFractionalDays(base time.Time, days float64) time.Time {
+	whole := math.Trunc(days)
+	return base.AddDate(0, 0, int(whole)).Add(scaleDuration(days-whole, HoursInDay*time.Hour))
+}
+
+// wholeMonths converts a month, quarter or year amount into months.
+// Fractions are accepted only when they add up to whole months (e.g. "1.5Y").
+func wholeMonths(num float64, unit string) (int, error) {
+	months := num
+	switch unit {
+	case "q":
+		months *= MonthsInQuarter
+	case "Y":
+		months *= MonthsInYear
+	}
+	if months != math.Trunc(months) {
+		return 0, fmt.Errorf("%w: %g%s is not a whole number of months", ErrInvalidNumberFormat, num, unit)
+	}
+	return int(months), nil
+}
+
+func parseISODate(value string, loc *time.Location, policy TZAbbrevPolicy) (time.Time, error) {
 	// Try various ISO date formats
 	formats := []string{
 		"2006-01-02T15:04:05Z07:00",
@@ -183,8 +280,9 @@ func parseISODate(value string, loc *time.Location) (time.Time, error) {
 	}
 	
 	// Try parsing with timezone name at the end (e.g., "2006-01-02 15:04:05 UTC")
-	if t, err := parseWithTimezoneName(value); err == nil {
-		return t, nil
+	t, err := parseWithTimezoneName(value, policy)
+	if err == nil || errors.Is(err, ErrAmbiguousTimezoneAbbrev) {
+		return t, err
 	}
 	
 	return time.Time{}, fmt.Errorf("%w: %s", ErrInvalidISODateFormat, value)
@@ -208,19 +306,109 @@ func parseTimeOnly(value string, baseDate time.Time, loc *time.Location) (time.T
 	return time.Time{}, fmt.Errorf("%w: %s", ErrInvalidTime, value)
 }
 
-// ApplyOperation applies an operation to a date.
+// ApplyOperation applies an operation to a date. Wall clocks skipped or
+// repeated by DST resolve with DSTLater.
 func ApplyOperation(date time.Time, op, value string, loc *time.Location) (time.Time, error) {
+	t, _, err := applyOperation(date, op, value, loc, operationOptions{})
+	return t, err
+}
+
+// operationOptions carries the EvalContext settings operations depend on.
+// The zero value gives the defaults.
+type operationOptions struct {
+	dst       DSTPolicy
+	months    MonthOverflowPolicy
+	week      *Week
+	fiscal    FiscalCalendar
+	retail    RetailCalendar
+	calendars map[string]*Calendar
+}
+
+func (ctx *EvalContext) operationOptions() operationOptions {
+	return operationOptions{
+		dst:       ctx.DSTPolicy,
+		months:    ctx.MonthOverflow,
+		week:      ctx.Week,
+		fiscal:    FiscalCalendar{StartMonth: ctx.FiscalYearStart},
+		retail:    ctx.Retail,
+		calendars: ctx.Calendars,
+	}
+}
+
+func (o operationOptions) weekOrDefault() Week {
+	if o.week == nil {
+		return ISOWeek
+	}
+	return *o.week
+}
+
+// applyOperation applies an operation and resolves the wall clock it asks
+// for with the DST policy. It also reports whether that wall clock exists. Operations
+// that set a wall clock ("time 02:30", "startOfDay", "+1d") are replayed in
+// UTC, where no wall clock is skipped, to find it; the others produce an
+// exact instant, which may only be ambiguous.
+func applyOperation(
+	date time.Time, op, value string, loc *time.Location, opts operationOptions,
+) (time.Time, LocalTimeStatus, error) {
+	result, err := dispatchOperation(date, op, value, loc, opts)
+	if err != nil {
+		return time.Time{}, LocalTimeValid, err
+	}
+	if !isWallClockOperation(op, value) {
+		return result, CheckLocalTime(result, result.Location()), nil
+	}
+	
+	wall, err := dispatchOperation(wallClockInUTC(date), op, value, time.UTC, opts)
+	if err != nil {
+		return result, CheckLocalTime(result, result.Location()), nil //nolint:nilerr // the result stands
+	}
+	status := CheckLocalTime(wall, result.Location())
+	if status == LocalTimeValid {
+		return result, status, nil
+	}
+	resolved, err := ResolveLocalTime(wall, result.Location(), opts.dst)
+	return resolved, status, err
+}
+
+// isWallClockOperation reports whether the operation works on wall clocks
+// (calendar arithmetic, boundaries, time/day setters) rather than on instants.
+func isWallClockOperation(op, value string) bool {
+	switch op {
+	case "time", "day", "next", "prev":
+		return true
+	case "round", "trunc":
+		return value == "day" || value == "week" || value == "month" || value == "quarter" || value == "year"
+	case "+", "-":
+		components, err := parseRelativeComponents(value, 0)
+		if err != nil {
+			return false
+		}
+		for _, c := range components {
+			if len(c.unit) != 1 || !strings.Contains("dwMqY", c.unit) || c.value != math.Trunc(c.value) {
+				return false
+			}
+		}
+		return true
+	default:
+		return strings.HasPrefix(op, "startof") || strings.HasPrefix(op, "endof")
+	}
+}
+
+// dispatchOperation finds the operation and applies it.
+func dispatchOperation(
+	date time.Time, op, value string, loc *time.Location, opts operationOptions,
+) (time.Time, error) {
 	if loc == nil {
 		loc = time.Local //nolint:gosmopolitan // intentional default to local timezone
 	}
 	
 	// Handle arithmetic operations
-	if result, ok := applyArithmeticOperation(date, op, value, loc); ok {
+	if result, ok := applyArithmeticOperation(date, op, value, opts.months); ok {
 		return result.t, result.err
 	}
 	
 	// Handle boundary operations
-	if result, ok := applyBoundaryOperation(date, op, loc); ok {
+	if result, ok := applyBoundaryOperation(date, op, loc, opts); ok {
 		return result.t, result.err
 	}
 	
@@ -234,6 +422,26 @@ func ApplyOperation(date time.Time, op, value string, loc *time.Location) (time.
 		return result.t, result.err
 	}
 	
+	// Handle weekday navigation operations
+	if result, ok := applyWeekdayOperation(date, op, value); ok {
+		return result.t, result.err
+	}
+	
+	// Handle cron schedules
+	if result, ok := applyCronOperation(date, op, value); ok {
+		return result.t, result.err
+	}
+	
+	// Handle calendar events
+	if result, ok := applyEventOperation(date, op, value, opts.calendars); ok {
+		return result.t, result.err
+	}
+	
+	// Handle timezone conversions
+	if result, ok := applyTimezoneOperation(date, op, value); ok {
+		return result.t, result.err
+	}
+	
 	return time.Time{}, fmt.Errorf("%w: %s", ErrUnknownOperation, op)
 }
 
@@ -242,24 +450,28 @@ type operationResult struct {
 	err error
 }
 
-func applyArithmeticOperation(date time.Time, op, value string, loc *time.Location) (operationResult, bool) {
+func applyArithmeticOperation(
+	date time.Time, op, value string, months MonthOverflowPolicy,
+) (operationResult, bool) {
 	switch op {
 	case "+":
-		t, err := applyRelativeDate(date, "+"+value, loc)
+		t, err := applyRelativeOffset(date, "+"+value, months)
 		return operationResult{t, err}, true
 	case "-":
-		t, err := applyRelativeDate(date, "-"+value, loc)
+		t, err := applyRelativeOffset(date, "-"+value, months)
 		return operationResult{t, err}, true
 	default:
 		return operationResult{}, false
 	}
 }
 
-func applyBoundaryOperation(date time.Time, op string, loc *time.Location) (operationResult, bool) {
+func applyBoundaryOperation(
+	date time.Time, op string, loc *time.Location, opts operationOptions,
+) (operationResult, bool) {
 	if result, ok := applyDayBoundaryOps(date, op, loc); ok {
 		return result, true
 	}
-	if result, ok := applyWeekBoundaryOps(date, op, loc); ok {
+	if result, ok := applyWeekBoundaryOps(date, op, opts.weekOrDefault()); ok {
 		return result, true
 	}
 	if result, ok := applyMonthBoundaryOps(date, op, loc); ok {
@@ -271,6 +483,12 @@ func applyBoundaryOperation(date time.Time, op string, loc *time.Location) (oper
 	if result, ok := applyTimeBoundaryOps(date, op, loc); ok {
 		return result, true
 	}
+	if result, ok := applyFiscalBoundaryOps(date, op, opts.fiscal); ok {
+		return result, true
+	}
+	if result, ok := applyRetailBoundaryOps(date, op, opts.retail); ok {
+		return result, true
+	}
 	return operationResult{}, false
 }
 
@@ -285,12 +503,12 @@ func applyDayBoundaryOps(date time.Time, op string, loc *time.Location) (operati
 	}
 }
 
-func applyWeekBoundaryOps(date time.Time, op string, loc *time.Location) (operationResult, bool) {
+func applyWeekBoundaryOps(date time.Time, op string, week Week) (operationResult, bool) {
 	switch op {
 	case "startofweek":
-		return operationResult{startOfWeek(date, loc), nil}, true
+		return operationResult{startOfWeek(date, week), nil}, true
 	case "endofweek":
-		return operationResult{endOfWeek(date, loc), nil}, true
+		return operationResult{endOfWeek(date, week), nil}, true
 	default:
 		return operationResult{}, false
 	}
@@ -381,6 +599,79 @@ func applyTransformOperation(date time.Time, op, value string, loc *time.Locatio
 	}
 }
 
+func applyWeekdayOperation(date time.Time, op, value string) (operationResult, bool) {
+	switch op {
+	case "next", "prev":
+		if !isWeekdayName(value) {
+			return operationResult{time.Time{}, fmt.Errorf("%w: %s", ErrInvalidWeekday, value)}, true
+		}
+		step := 1
+		if op == "prev" {
+			step = -1
+		}
+		return operationResult{stepToWeekday(date, parseWeekday(value), step), nil}, true
+	default:
+		return operationResult{}, false
+	}
+}
+
+// applyTimezoneOperation converts the date to another location without
+// changing the instant: "tz America/New_York" or "utc".
+func applyTimezoneOperation(date time.Time, op, value string) (operationResult, bool) {
+	switch op {
+	case "utc":
+		return operationResult{date.UTC(), nil}, true
+	case "tz":
+		loc, err := LoadTimezone(value)
+		if err != nil {
+			return operationResult{time.Time{}, err}, true
+		}
+		return operationResult{date.In(loc), nil}, true
+	default:
+		return operationResult{}, false
+	}
+}
+
+// IsTimezoneOperation reports whether op changes the location of the date.
+func IsTimezoneOperation(op string) bool {
+	return op == "tz" || op == "utc"
+}
+
+// LoadTimezone loads an IANA location by name. Unlike time.LoadLocation, an
+// empty name is rejected rather than treated as UTC, and "utc" is accepted in any case.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%w: missing timezone name", ErrInvalidTimezone)
+	}
+	if strings.EqualFold(name, "utc") {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTimezone, name)
+	}
+	return loc, nil
+}
+
+// stepToWeekday moves day by day in the given direction until the target
+// weekday is reached, strictly after (or before) date, and returns the start of that day.
+func stepToWeekday(date time.Time, target time.Weekday, step int) time.Time {
+	day := date.AddDate(0, 0, step)
+	for day.Weekday() != target {
+		day = day.AddDate(0, 0, step)
+	}
+	return startOfDay(day, date.Location())
+}
+
+func isWeekdayName(name string) bool {
+	switch strings.ToLower(name) {
+	case "monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday":
+		return true
+	default:
+		return false
+	}
+}
+
 func startOfDay(t time.Time, _ *time.Location) time.Time {
 	// Preserve the original timezone of the input time
 	originalLoc := t.Location()
@@ -394,28 +685,22 @@ func endOfDay(t time.Time, _ *time.Location) time.Time {
 	return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, maxNanos, originalLoc)
 }
 
-func startOfWeek(t time.Time, _ *time.Location) time.Time {
-	// Adjust to Monday
-	weekday := int(t.Weekday())
-	if weekday == 0 {
-		weekday = DaysInWeek
-	}
-	monday := t.AddDate(0, 0, -(weekday - 1))
+func startOfWeek(t time.Time, week Week) time.Time {
+	// Go back to the first day of the week
+	daysSinceStart := (int(t.Weekday()) - int(week.Start) + DaysInWeek) % DaysInWeek
+	first := t.AddDate(0, 0, -daysSinceStart)
 	// Preserve the original timezone of the input time
 	originalLoc := t.Location()
-	return startOfDay(monday, originalLoc)
+	return startOfDay(first, originalLoc)
 }
 
-func endOfWeek(t time.Time, _ *time.Location) time.Time {
-	// Adjust to Sunday
-	weekday := int(t.Weekday())
-	if weekday == 0 {
-		weekday = DaysInWeek
-	}
-	sunday := t.AddDate(0, 0, DaysInWeek-weekday)
+func endOfWeek(t time.Time, week Week) time.Time {
+	// Go forward to the last day of the week
+	daysSinceStart := (int(t.Weekday()) - int(week.Start) + DaysInWeek) % DaysInWeek
+	last := t.AddDate(0, 0, DaysInWeek-1-daysSinceStart)
 	// Preserve the original timezone of the input time
 	originalLoc := t.Location()
-	return endOfDay(sunday, originalLoc)
+	return endOfDay(last, originalLoc)
 }
 
 func startOfMonth(t time.Time, _ *time.Location) time.Time {
@@ -504,17 +789,17 @@ func roundDate(t time.Time, unit string, loc *time.Location) (time.Time, error)
 		// Round to nearest hour
 		minute := t.Minute()
 		if minute >= HalfMinute {
-			return t.Add(time.Hour).Truncate(time.Hour), nil
+			return startOfHour(t.Add(time.Hour), loc), nil
 		}
-		return t.Truncate(time.Hour), nil
+		return startOfHour(t, loc), nil
 		
 	case "minute":
 		// Round to nearest minute
 		second := t.Second()
 		if second >= HalfSecond {
-			return t.Add(time.Minute).Truncate(time.Minute), nil
+			return startOfMinute(t.Add(time.Minute), loc), nil
 		}
-		return t.Truncate(time.Minute), nil
+		return startOfMinute(t, loc), nil
 		
 	default:
 		return time.Time{}, fmt.Errorf("%w: %s", ErrInvalidUnit, unit)
@@ -526,9 +811,11 @@ func truncateDate(t time.Time, unit string, loc *time.Location) (time.Time, erro
 	case "day", "":
 		return startOfDay(t, loc), nil
 	case "hour":
-		return t.Truncate(time.Hour), nil
+		// On the wall clock: time.Truncate works on absolute time, which
+		// is off by the half-hour of zones like Asia/Kolkata
+		return startOfHour(t, loc), nil
 	case "minute":
-		return t.Truncate(time.Minute), nil
+		return startOfMinute(t, loc), nil
 	default:
 		return time.Time{}, fmt.Errorf("%w: %s", ErrInvalidUnit, unit)
 	}
@@ -573,7 +860,7 @@ func endOfSecond(t time.Time, _ *time.Location) time.Time {
 	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), maxNanos, originalLoc)
 }
 
-func parseWithTimezoneName(value string) (time.Time, error) {
+func parseWithTimezoneName(value string, policy TZAbbrevPolicy) (time.Time, error) {
 	// Split by space to find potential timezone name at the end
 	parts := strings.Fields(value)
 	const minPartsForTimezone = 2
@@ -585,14 +872,15 @@ func parseWithTimezoneName(value string) (time.Time, error) {
 	tzName := parts[len(parts)-1]
 	const minTzNameLength = 2
 	const maxTzNameLength = 5
-	if len(tzName) < minTzNameLength || len(tzName) > maxTzNameLength {
+	isLocation := strings.Contains(tzName, "/")
+	if !isLocation && (len(tzName) < minTzNameLength || len(tzName) > maxTzNameLength) {
 		return time.Time{}, fmt.Errorf("%w: %s", ErrInvalidISODateFormat, value)
 	}
 	
-	// Try to load the timezone
-	loc, err := time.LoadLocation(tzName)
+	// Resolve the abbreviation, falling back to IANA names like "UTC"
+	loc, err := resolveTimezoneName(tzName, policy)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("%w: %s", ErrInvalidTimezone, tzName)
+		return time.Time{}, err
 	}
 	
 	// Reconstruct the date/time part without the timezone name
@@ -614,4 +902,17 @@ func parseWithTimezoneName(value string) (time.Time, error) {
 	}
 	
 	return time.Time{}, fmt.Errorf("%w: %s", ErrInvalidISODateFormat, value)
-}
\ No newline at end of file
+}
+
+// resolveTimezoneName resolves a trailing zone name of a date literal: a known
+// abbreviation first, then an IANA location name.
+func resolveTimezoneName(name string, policy TZAbbrevPolicy) (*time.Location, error) {
+	if IsTimezoneAbbreviation(name) {
+		return ResolveTimezoneAbbreviation(name, policy)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTimezone, name)
+	}
+	return loc, nil
+}
@@ -0,0 +1,117 @@
+package calcdate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Partition is a partition of a time-partitioned table, holding the rows
+// within [From, To).
+type Partition struct {
+	Name     string
+	From, To time.Time
+}
+
+// NewPartitions names iteration rows with a Unix date format pattern such as
+// "events_%Y_%m", formatted at the start of each row. Two rows with the same
+// name (a pattern coarser than the interval) are an error.
+func NewPartitions(rows []IterationResult, pattern string, f Formatter) ([]Partition, error) {
+	partitions := make([]Partition, 0, len(rows))
+	seen := map[string]bool{}
+	for _, row := range rows {
+		name := f.Format(row.BeginTime, pattern)
+		if seen[name] {
+			return nil, fmt.Errorf("%w: %q names two partitions %s", ErrInvalidPartitionPattern, pattern, name)
+		}
+		seen[name] = true
+		partitions = append(partitions, Partition{Name: name, From: row.BeginTime, To: row.EndTime})
+	}
+	return partitions, nil
+}
+
+// PartitionsToDrop returns the partitions ending at or before cutoff: those
+// holding only rows older than the retention.
+func PartitionsToDrop(partitions []Partition, cutoff time.Time) []Partition {
+	drop := []Partition{}
+	for _, p := range partitions {
+		if !p.To.After(cutoff) {
+			drop = append(drop, p)
+		}
+	}
+	return drop
+}
+
+// CreatePartitionsDDL returns the statements creating partitions of table:
+//
+//   - DialectPostgres: one CREATE TABLE ... PARTITION OF table FOR VALUES
+//     FROM (...) TO (...) per declarative partition;
+//   - DialectMySQL: ALTER TABLE table ADD PARTITION with VALUES LESS THAN
+//     DATETIME literals, which only tables partitioned BY RANGE COLUMNS on a
+//     DATETIME accept (not BY RANGE on TO_DAYS or UNIX_TIMESTAMP).
+//
+// Partition tables are created in the schema of table. BigQuery partitions
+// tables by itself, without DDL per partition, and is not supported.
+func CreatePartitionsDDL(table string, partitions []Partition, dialect SQLDialect) (string, error) {
+	var b strings.Builder
+	switch dialect {
+	case DialectPostgres:
+		for _, p := range partitions {
+			fmt.Fprintf(&b, "CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM (%s) TO (%s);\n",
+				qualifyPartition(table, p.Name), table, partitionBound(p.From, dialect), partitionBound(p.To, dialect))
+		}
+	case DialectMySQL:
+		if len(partitions) == 0 {
+			return "", nil
+		}
+		defs := make([]string, len(partitions))
+		for i, p := range partitions {
+			defs[i] = fmt.Sprintf("  PARTITION %s VALUES LESS THAN (%s)", p.Name, partitionBound(p.To, dialect))
+		}
+		fmt.Fprintf(&b, "ALTER TABLE %s ADD PARTITION (\n%s\n);\n", table, strings.Join(defs, ",\n"))
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedPartitionDialect, dialect)
+	}
+	return b.String(), nil
+}
+
+// DropPartitionsDDL returns the statements dropping partitions of table, the
+// counterpart of CreatePartitionsDDL.
+func DropPartitionsDDL(table string, partitions []Partition, dialect SQLDialect) (string, error) {
+	var b strings.Builder
+	switch dialect {
+	case DialectPostgres:
+		for _, p := range partitions {
+			fmt.Fprintf(&b, "DROP TABLE IF EXISTS %s;\n", qualifyPartition(table, p.Name))
+		}
+	case DialectMySQL:
+		if len(partitions) == 0 {
+			return "", nil
+		}
+		names := make([]string, len(partitions))
+		for i, p := range partitions {
+			names[i] = p.Name
+		}
+		fmt.Fprintf(&b, "ALTER TABLE %s DROP PARTITION %s;\n", table, strings.Join(names, ", "))
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedPartitionDialect, dialect)
+	}
+	return b.String(), nil
+}
+
+// qualifyPartition prefixes a partition name with the schema of table ("analytics.events" gives "analytics.events_2025_03").
+func qualifyPartition(table, name string) string {
+	if i := strings.LastIndex(table, "."); i >= 0 && !strings.Contains(name, ".") {
+		return table[:i+1] + name
+	}
+	return name
+}
+
+// partitionBound returns a partition bound literal: with its UTC offset for
+// PostgreSQL timestamptz keys, a wall clock for MySQL DATETIME keys.
+func partitionBound(t time.Time, dialect SQLDialect) string {
+	if dialect == DialectMySQL {
+		return SQLLiteral(t, DialectMySQL)
+	}
+	return "'" + t.Format("2006-01-02 15:04:05.999999-07:00") + "'"
+}
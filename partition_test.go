@@ -0,0 +1,89 @@
+package calcdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func monthRows(t *testing.T, months int) []IterationResult {
+	t.Helper()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, mustLoad(t, "Europe/Paris"))
+	end := start.AddDate(0, months, 0)
+	rows, err := IterateWithSpecialIntervalContext(start, end, "1M", nil, &EvalContext{Timezone: start.Location()})
+	require.NoError(t, err)
+	return rows
+}
+
+func TestNewPartitions(t *testing.T) {
+	partitions, err := NewPartitions(monthRows(t, 3), "events_%Y_%m", Formatter{})
+	require.NoError(t, err)
+	require.Len(t, partitions, 3)
+	assert.Equal(t, "events_2025_01", partitions[0].Name)
+	assert.Equal(t, "events_2025_03", partitions[2].Name)
+	assert.Equal(t, "2025-04-01", partitions[2].To.Format(time.DateOnly))
+
+	_, err = NewPartitions(monthRows(t, 3), "events_%Y", Formatter{})
+	require.ErrorIs(t, err, ErrInvalidPartitionPattern)
+}
+
+func TestPartitionsToDrop(t *testing.T) {
+	partitions, err := NewPartitions(monthRows(t, 6), "p%Y%m", Formatter{})
+	require.NoError(t, err)
+	paris := mustLoad(t, "Europe/Paris")
+
+	drop := PartitionsToDrop(partitions, time.Date(2025, 3, 15, 0, 0, 0, 0, paris))
+	require.Len(t, drop, 2)
+	assert.Equal(t, "p202502", drop[1].Name)
+
+	// a partition ending exactly at the cutoff holds nothing to keep
+	assert.Len(t, PartitionsToDrop(partitions, time.Date(2025, 3, 1, 0, 0, 0, 0, paris)), 2)
+	assert.Empty(t, PartitionsToDrop(partitions, time.Date(2024, 12, 1, 0, 0, 0, 0, paris)))
+}
+
+func TestCreatePartitionsDDL(t *testing.T) {
+	partitions, err := NewPartitions(monthRows(t, 2), "events_%Y_%m", Formatter{})
+	require.NoError(t, err)
+
+	ddl, err := CreatePartitionsDDL("app.events", partitions, DialectPostgres)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"CREATE TABLE IF NOT EXISTS app.events_2025_01 PARTITION OF app.events "+
+			"FOR VALUES FROM ('2025-01-01 00:00:00+01:00') TO ('2025-02-01 00:00:00+01:00');\n"+
+			"CREATE TABLE IF NOT EXISTS app.events_2025_02 PARTITION OF app.events "+
+			"FOR VALUES FROM ('2025-02-01 00:00:00+01:00') TO ('2025-03-01 00:00:00+01:00');\n", ddl)
+
+	ddl, err = CreatePartitionsDDL("events", partitions, DialectMySQL)
+	require.NoError(t, err)
+	assert.Equal(t, "ALTER TABLE events ADD PARTITION (\n"+
+		"  PARTITION events_2025_01 VALUES LESS THAN ('2025-02-01 00:00:00'),\n"+
+		"  PARTITION events_2025_02 VALUES LESS THAN ('2025-03-01 00:00:00')\n"+
+		");\n", ddl)
+
+	ddl, err = CreatePartitionsDDL("events", nil, DialectMySQL)
+	require.NoError(t, err)
+	assert.Empty(t, ddl)
+
+	_, err = CreatePartitionsDDL("events", partitions, DialectSQLite)
+	require.ErrorIs(t, err, ErrUnsupportedPartitionDialect)
+	_, err = CreatePartitionsDDL("ds.events", partitions, DialectBigQuery)
+	require.ErrorIs(t, err, ErrUnsupportedPartitionDialect)
+}
+
+func TestDropPartitionsDDL(t *testing.T) {
+	partitions, err := NewPartitions(monthRows(t, 2), "events_%Y_%m", Formatter{})
+	require.NoError(t, err)
+
+	ddl, err := DropPartitionsDDL("app.events", partitions, DialectPostgres)
+	require.NoError(t, err)
+	assert.Equal(t, "DROP TABLE IF EXISTS app.events_2025_01;\nDROP TABLE IF EXISTS app.events_2025_02;\n", ddl)
+
+	ddl, err = DropPartitionsDDL("events", partitions, DialectMySQL)
+	require.NoError(t, err)
+	assert.Equal(t, "ALTER TABLE events DROP PARTITION events_2025_01, events_2025_02;\n", ddl)
+
+	_, err = DropPartitionsDDL("events", partitions, DialectClickHouse)
+	require.ErrorIs(t, err, ErrUnsupportedPartitionDialect)
+}
@@ -0,0 +1,174 @@
+package calcdate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// PredicateFunc tests an evaluated date. status is the LocalTimeStatus
+// returned by EvaluateLocalTime and ctx the context it was evaluated in.
+type PredicateFunc func(t time.Time, status LocalTimeStatus, ctx *EvalContext) bool
+
+// namedPredicate is a predicate usable in predicate expressions.
+type namedPredicate struct {
+	name string
+	test PredicateFunc
+}
+
+// predicates lists the known predicates. Names are matched case-insensitively.
+var predicates = []namedPredicate{
+	{"isAmbiguous", func(_ time.Time, status LocalTimeStatus, _ *EvalContext) bool {
+		return status == LocalTimeAmbiguous
+	}},
+	{"isNonexistent", func(_ time.Time, status LocalTimeStatus, _ *EvalContext) bool {
+		return status == LocalTimeNonexistent
+	}},
+	{"isValidLocal", func(_ time.Time, status LocalTimeStatus, _ *EvalContext) bool {
+		return status == LocalTimeValid
+	}},
+	{"isDST", func(t time.Time, _ LocalTimeStatus, _ *EvalContext) bool {
+		return t.IsDST()
+	}},
+	{"isWeekend", func(t time.Time, _ LocalTimeStatus, ctx *EvalContext) bool {
+		return ctx.week().IsWeekend(t)
+	}},
+	{"isWorkday", func(t time.Time, _ LocalTimeStatus, ctx *EvalContext) bool {
+		return ctx.week().IsWorkday(t)
+	}},
+}
+
+func lookupPredicate(name string) (PredicateFunc, bool) {
+	for _, p := range predicates {
+		if strings.EqualFold(p.name, name) {
+			return p.test, true
+		}
+	}
+	return nil, false
+}
+
+// inEventPredicate is the predicate taking a calendar name: "isInEvent freeze"
+// matches dates within an event of the calendar registered as "freeze" in
+// EvalContext.Calendars.
+const inEventPredicate = "isInEvent"
+
+// inEvent returns the test of "isInEvent name". Dates never match an unknown
+// calendar; see Predicate.Calendars.
+func inEvent(name string) PredicateFunc {
+	return func(t time.Time, _ LocalTimeStatus, ctx *EvalContext) bool {
+		cal, err := lookupCalendar(ctx.Calendars, name)
+		if err != nil {
+			return false
+		}
+		in, err := cal.Contains(t)
+		return err == nil && in
+	}
+}
+
+// Predicate is a parsed predicate expression such as "isAmbiguous",
+// "not isDST" or "isAmbiguous or isNonexistent". "and" binds tighter than
+// "or"; parentheses are not supported.
+type Predicate struct {
+	source string
+	// anyOf holds the "or" alternatives, each a list of terms that must all match
+	anyOf [][]predicateTerm
+	// calendars lists the calendar names of isInEvent terms
+	calendars []string
+}
+
+type predicateTerm struct {
+	negate bool
+	test   PredicateFunc
+}
+
+// ParsePredicate parses a predicate expression.
+func ParsePredicate(expr string) (Predicate, error) {
+	p := Predicate{source: strings.TrimSpace(expr)}
+	words := strings.Fields(strings.ToLower(expr))
+	if len(words) == 0 {
+		return Predicate{}, fmt.Errorf("%w: empty predicate", ErrInvalidPredicate)
+	}
+
+	group := []predicateTerm{}
+	negate := false
+	expectTerm := true
+	expectCalendar := false
+	for _, word := range words {
+		switch {
+		case expectCalendar:
+			group = append(group, predicateTerm{negate: negate, test: inEvent(word)})
+			p.calendars = append(p.calendars, word)
+			negate, expectTerm, expectCalendar = false, false, false
+		case expectTerm && strings.EqualFold(word, inEventPredicate):
+			expectCalendar = true
+		case expectTerm && word == "not":
+			negate = !negate
+		case expectTerm:
+			test, ok := lookupPredicate(word)
+			if !ok {
+				return Predicate{}, fmt.Errorf("%w: unknown predicate %q (known: %s)",
+					ErrInvalidPredicate, word, strings.Join(PredicateNames(), ", "))
+			}
+			group = append(group, predicateTerm{negate: negate, test: test})
+			negate, expectTerm = false, false
+		case word == "and":
+			expectTerm = true
+		case word == "or":
+			p.anyOf = append(p.anyOf, group)
+			group = []predicateTerm{}
+			expectTerm = true
+		default:
+			return Predicate{}, fmt.Errorf("%w: expected 'and' or 'or', got %q", ErrInvalidPredicate, word)
+		}
+	}
+	if expectCalendar {
+		return Predicate{}, fmt.Errorf("%w: %s expects a calendar name", ErrInvalidPredicate, inEventPredicate)
+	}
+	if expectTerm {
+		return Predicate{}, fmt.Errorf("%w: %q ends with an operator", ErrInvalidPredicate, expr)
+	}
+	p.anyOf = append(p.anyOf, group)
+	return p, nil
+}
+
+// Match evaluates the predicate on t, evaluated in ctx (nil for defaults)
+// with the given LocalTimeStatus.
+func (p Predicate) Match(t time.Time, status LocalTimeStatus, ctx *EvalContext) bool {
+	if ctx == nil {
+		ctx = &EvalContext{}
+	}
+	for _, group := range p.anyOf {
+		if matchAll(group, t, status, ctx) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchAll(terms []predicateTerm, t time.Time, status LocalTimeStatus, ctx *EvalContext) bool {
+	for _, term := range terms {
+		if term.test(t, status, ctx) == term.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the predicate expression.
+func (p Predicate) String() string {
+	return p.source
+}
+
+// Calendars returns the calendar names used by isInEvent terms, lower-cased.
+func (p Predicate) Calendars() []string {
+	return p.calendars
+}
+
+// PredicateNames lists the known predicate names.
+func PredicateNames() []string {
+	names := make([]string, 0, len(predicates)+1)
+	for _, p := range predicates {
+		names = append(names, p.name)
+	}
+	return append(names, inEventPredicate)
+}
@@ -13,6 +13,12 @@ type RangeIterator struct {
 	Transform *TransformNode
 	Timezone  *time.Location
 	Index     int
+	// Week, when set, aligns iterations on its week starts: the first one
+	// ends at the first boundary after Start, the others span whole
+	// intervals. Only intervals of whole days are aligned.
+	Week *Week
+	// Context, when set, provides the settings transforms are evaluated with.
+	Context *EvalContext
 }
 
 // IterationResult represents a single iteration result.
@@ -94,12 +100,36 @@ func (r *RangeIterator) iterateWithInterval() ([]IterationResult, error) {
 
 func (r *RangeIterator) calculateIterationEnd(currentBegin time.Time) time.Time {
 	currentEnd := currentBegin.Add(r.Interval)
+	if days, ok := r.alignedDays(); ok {
+		currentEnd = nextAlignedBoundary(r.Week.StartOf(r.Start), days, currentBegin)
+	}
 	if currentEnd.After(r.End) {
 		currentEnd = r.End
 	}
 	return currentEnd
 }
 
+// alignedDays returns the interval in days when iterations are aligned on weeks.
+func (r *RangeIterator) alignedDays() (int, bool) {
+	day := HoursInDay * time.Hour
+	if r.Week == nil || r.Interval < day || r.Interval%day != 0 {
+		return 0, false
+	}
+	return int(r.Interval / day), true
+}
+
+// nextAlignedBoundary returns the first of anchor, anchor + days,
+// anchor + 2*days... after t. Boundaries are calendar days, so they stay at
+// midnight across DST changes.
+func nextAlignedBoundary(anchor time.Time, days int, t time.Time) time.Time {
+	elapsed := int(t.Sub(anchor).Hours()) / HoursInDay
+	boundary := anchor.AddDate(0, 0, elapsed/days*days)
+	for !boundary.After(t) {
+		boundary = boundary.AddDate(0, 0, days)
+	}
+	return boundary
+}
+
 func (r *RangeIterator) isInvalidRange(begin, end time.Time) bool {
 	return begin.Equal(end) || end.Sub(begin) < time.Second
 }
@@ -109,9 +139,12 @@ func (r *RangeIterator) applyTransform(begin, end time.Time, index int) (time.Ti
 		return begin, end, nil
 	}
 	
-	ctx := &EvalContext{
-		Now:      time.Now(),
-		Timezone: r.Timezone,
+	ctx := r.Context
+	if ctx == nil {
+		ctx = &EvalContext{
+			Now:      time.Now(),
+			Timezone: r.Timezone,
+		}
 	}
 	return EvaluateTransform(r.Transform, begin, end, index, ctx)
 }
@@ -135,45 +168,47 @@ func parseCustomInterval(interval string) (time.Duration, error) {
 		return 0, fmt.Errorf("%w: %s", ErrInvalidInterval, interval)
 	}
 	
-	num, unit, err := parseIntervalComponents(interval)
+	components, err := parseIntervalComponents(interval)
 	if err != nil {
 		return 0, err
 	}
 	
-	return convertUnitToDuration(num, unit)
+	// Compound intervals like "1d12h" add up their components
+	var total time.Duration
+	for _, c := range components {
+		dur, err := convertUnitToDuration(c.value, c.unit)
+		if err != nil {
+			return 0, err
+		}
+		total += dur
+	}
+	return total, nil
 }
 
-func parseIntervalComponents(interval string) (int, string, error) {
-	// Extract number and unit
-	unitIdx := 0
-	for unitIdx < len(interval) && (interval[unitIdx] >= '0' && interval[unitIdx] <= '9') {
-		unitIdx++
-	}
-	
-	if unitIdx == 0 || unitIdx >= len(interval) {
-		return 0, "", fmt.Errorf("%w: %s", ErrInvalidInterval, interval)
+func parseIntervalComponents(interval string) ([]relativeComponent, error) {
+	if interval == "" || !isDigit(interval[0]) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInterval, interval)
 	}
 	
-	num, err := ParseInt(interval[:unitIdx])
+	components, err := parseRelativeComponents(interval, 0)
 	if err != nil {
-		return 0, "", fmt.Errorf("%w: %s", ErrInvalidNumberFormat, interval)
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInterval, interval)
 	}
-	
-	return num, interval[unitIdx:], nil
+	return components, nil
 }
 
-func convertUnitToDuration(num int, unit string) (time.Duration, error) {
+func convertUnitToDuration(num float64, unit string) (time.Duration, error) {
 	switch unit {
 	case "s":
-		return time.Duration(num) * time.Second, nil
+		return scaleDuration(num, time.Second), nil
 	case "m":
-		return time.Duration(num) * time.Minute, nil
+		return scaleDuration(num, time.Minute), nil
 	case "h":
-		return time.Duration(num) * time.Hour, nil
+		return scaleDuration(num, time.Hour), nil
 	case "d":
-		return time.Duration(num) * HoursInDay * time.Hour, nil
+		return scaleDuration(num, HoursInDay*time.Hour), nil
 	case "w":
-		return time.Duration(num) * DaysInWeek * HoursInDay * time.Hour, nil
+		return scaleDuration(num, DaysInWeek*HoursInDay*time.Hour), nil
 	default:
 		return 0, fmt.Errorf("%w: unit '%s' requires special handling", ErrInvalidInterval, unit)
 	}
@@ -192,30 +227,114 @@ func ParseInt(s string) (int, error) {
 // IterateWithSpecialInterval handles month and year intervals
 //nolint:lll // long function signature is readable
 func IterateWithSpecialInterval(start, end time.Time, interval string, transform *TransformNode, tz *time.Location) ([]IterationResult, error) {
-	num, unit, err := parseSpecialInterval(interval)
+	return IterateWithSpecialIntervalContext(start, end, interval, transform, &EvalContext{Timezone: tz})
+}
+
+// IterateWithSpecialIntervalContext handles month and year intervals with the
+// month overflow policy of ctx, which also evaluates the transform. Each
+// iteration ends one interval after its begin, except with MonthClamp where
+// iteration k ends at start + k intervals, so iterations anchored on the 31st
+// stay on month-ends instead of drifting to the 28th.
+//
+// Fiscal intervals ("1fq", "1fY") follow ctx.FiscalYearStart: the first
+// iteration ends at the next fiscal quarter (or year) boundary after start,
+// the others span whole fiscal periods. Retail periods ("1rp") follow
+// ctx.Retail the same way.
+//nolint:lll // long function signature is readable
+func IterateWithSpecialIntervalContext(start, end time.Time, interval string, transform *TransformNode, ctx *EvalContext) ([]IterationResult, error) {
+	components, err := parseSpecialInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+	
+	fiscalMonths, isFiscal, err := fiscalIntervalMonths(components)
+	if err != nil {
+		return nil, err
+	}
+	if isFiscal {
+		fiscal := FiscalCalendar{StartMonth: ctx.FiscalYearStart}
+		anchor := fiscal.StartOfQuarter(start)
+		if fiscalMonths%MonthsInYear == 0 {
+			anchor = fiscal.StartOfYear(start)
+		}
+		nextEnd := func(_ int, currentBegin time.Time) (time.Time, error) {
+			return nextMonthBoundary(anchor, fiscalMonths, currentBegin), nil
+		}
+		return performSpecialIteration(start, end, nextEnd, transform, ctx)
+	}
+	
+	periods, isRetail, err := retailIntervalPeriods(components)
 	if err != nil {
 		return nil, err
 	}
+	if isRetail {
+		nextEnd := func(_ int, currentBegin time.Time) (time.Time, error) {
+			boundary := ctx.Retail.StartOfPeriod(currentBegin)
+			for range periods {
+				boundary = ctx.Retail.nextPeriodStart(boundary)
+			}
+			return boundary, nil
+		}
+		return performSpecialIteration(start, end, nextEnd, transform, ctx)
+	}
 	
-	return performSpecialIteration(start, end, num, unit, transform, tz)
+	nextEnd := func(index int, currentBegin time.Time) (time.Time, error) {
+		if ctx.MonthOverflow == MonthClamp {
+			return calculateSpecialIntervalEnd(start, components, index+1, ctx.MonthOverflow)
+		}
+		return calculateSpecialIntervalEnd(currentBegin, components, 1, ctx.MonthOverflow)
+	}
+	return performSpecialIteration(start, end, nextEnd, transform, ctx)
+}
+
+// iterationEnd returns the end of the iteration index starting at currentBegin.
+type iterationEnd func(index int, currentBegin time.Time) (time.Time, error)
+
+// nextMonthBoundary returns the first of anchor, anchor + months,
+// anchor + 2*months... after t.
+func nextMonthBoundary(anchor time.Time, months int, t time.Time) time.Time {
+	elapsed := (t.Year()-anchor.Year())*MonthsInYear + int(t.Month()) - int(anchor.Month())
+	boundary := anchor.AddDate(0, elapsed/months*months, 0)
+	for !boundary.After(t) {
+		boundary = boundary.AddDate(0, months, 0)
+	}
+	return boundary
+}
+
+// IsCalendarInterval reports whether an interval has a calendar unit: months,
+// quarters, years, fiscal quarters or years, or retail periods, including
+// compound intervals like "1M15d". Such intervals are iterated with
+// IterateWithSpecialIntervalContext, the others with a RangeIterator.
+func IsCalendarInterval(interval string) bool {
+	components, err := parseSpecialInterval(interval)
+	if err != nil {
+		return false
+	}
+	for _, c := range components {
+		switch c.unit {
+		case "M", "q", "Y", "fq", "fY", "rp":
+			return true
+		}
+	}
+	return false
 }
 
-func parseSpecialInterval(interval string) (int, string, error) {
+func parseSpecialInterval(interval string) ([]relativeComponent, error) {
 	if len(interval) < MinIntervalLength {
-		return 0, "", fmt.Errorf("%w: %s", ErrInvalidInterval, interval)
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInterval, interval)
 	}
 	
 	return parseIntervalComponents(interval)
 }
 
 //nolint:lll // long function signature is readable
-func performSpecialIteration(start, end time.Time, num int, unit string, transform *TransformNode, tz *time.Location) ([]IterationResult, error) {
+func performSpecialIteration(start, end time.Time, nextEnd iterationEnd, transform *TransformNode, ctx *EvalContext) ([]IterationResult, error) {
 	results := []IterationResult{}
 	currentBegin := start
 	index := 0
 	
 	for currentBegin.Before(end) || currentBegin.Equal(end) {
-		currentEnd, err := calculateSpecialIntervalEnd(currentBegin, num, unit)
+		currentEnd, err := nextEnd(index, currentBegin)
 		if err != nil {
 			return nil, err
 		}
@@ -230,7 +349,7 @@ func performSpecialIteration(start, end time.Time, num int, unit string, transfo
 			break
 		}
 		
-		iterBegin, iterEnd, err := applySpecialTransform(currentBegin, currentEnd, index, transform, tz)
+		iterBegin, iterEnd, err := applySpecialTransform(currentBegin, currentEnd, index, transform, ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -252,17 +371,12 @@ func performSpecialIteration(start, end time.Time, num int, unit string, transfo
 	return results, nil
 }
 
-func calculateSpecialIntervalEnd(begin time.Time, num int, unit string) (time.Time, error) {
-	switch unit {
-	case "M":
-		return begin.AddDate(0, num, 0), nil
-	case "Y":
-		return begin.AddDate(num, 0, 0), nil
-	case "q":
-		return begin.AddDate(0, num*MonthsInQuarter, 0), nil
-	default:
-		return time.Time{}, fmt.Errorf("%w: %s", ErrInvalidUnit, unit)
-	}
+// calculateSpecialIntervalEnd adds count times a calendar interval such as
+// "1M", "1q" or "1M15d" to the anchor.
+func calculateSpecialIntervalEnd(
+	anchor time.Time, interval []relativeComponent, count int, months MonthOverflowPolicy,
+) (time.Time, error) {
+	return addRelativeComponents(anchor, interval, float64(count), months)
 }
 
 func isInvalidTimeRange(begin, end time.Time) bool {
@@ -270,14 +384,29 @@ func isInvalidTimeRange(begin, end time.Time) bool {
 }
 
 //nolint:lll // long function signature is readable
-func applySpecialTransform(begin, end time.Time, index int, transform *TransformNode, tz *time.Location) (time.Time, time.Time, error) {
+func applySpecialTransform(begin, end time.Time, index int, transform *TransformNode, ctx *EvalContext) (time.Time, time.Time, error) {
 	if transform == nil {
 		return begin, end, nil
 	}
 	
-	ctx := &EvalContext{
-		Now:      time.Now(),
-		Timezone: tz,
-	}
 	return EvaluateTransform(transform, begin, end, index, ctx)
-}
\ No newline at end of file
+}
+
+// occurrenceRows turns occurrences (cron fire times, recurrences) into
+// iteration rows whose begin and end are the occurrence, reshaped by the
+// transform when there is one.
+func occurrenceRows(occurrences []time.Time, transform *TransformNode, ctx *EvalContext) ([]IterationResult, error) {
+	results := make([]IterationResult, 0, len(occurrences))
+	for index, t := range occurrences {
+		begin, end := t, t
+		if transform != nil {
+			var err error
+			begin, end, err = EvaluateTransform(transform, t, t, index, ctx)
+			if err != nil {
+				return nil, err
+			}
+		}
+		results = append(results, IterationResult{BeginTime: begin, EndTime: end, Index: index})
+	}
+	return results, nil
+}
@@ -0,0 +1,232 @@
+package calcdate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RetailPattern is the number of weeks in each of the three periods of a
+// retail quarter. The zero value is Retail445.
+type RetailPattern [3]int
+
+// Retail calendar patterns.
+var (
+	Retail445 = RetailPattern{4, 4, 5}
+	Retail454 = RetailPattern{4, 5, 4}
+	Retail544 = RetailPattern{5, 4, 4}
+)
+
+// RetailPatterns lists the patterns accepted by ParseRetailPattern.
+var RetailPatterns = []RetailPattern{Retail445, Retail454, Retail544}
+
+// String returns the pattern as "4-4-5".
+func (p RetailPattern) String() string {
+	return fmt.Sprintf("%d-%d-%d", p[0], p[1], p[2])
+}
+
+// ParseRetailPattern parses "4-4-5", "4-5-4" or "5-4-4"; "" is Retail445.
+func ParseRetailPattern(name string) (RetailPattern, error) {
+	if name == "" {
+		return Retail445, nil
+	}
+	for _, pattern := range RetailPatterns {
+		if strings.TrimSpace(name) == pattern.String() {
+			return pattern, nil
+		}
+	}
+	return RetailPattern{}, fmt.Errorf("%w: %s", ErrUnknownRetailPattern, name)
+}
+
+// RetailYearEnd selects the Saturday ending a retail year. The zero value
+// is RetailYearEndLast.
+type RetailYearEnd string
+
+// Retail year-end rules.
+const (
+	// RetailYearEndLast ends the year on the last Saturday of January.
+	RetailYearEndLast RetailYearEnd = "last"
+	// RetailYearEndNearest ends the year on the Saturday nearest to January 31,
+	// which may fall in early February.
+	RetailYearEndNearest RetailYearEnd = "nearest"
+)
+
+// RetailYearEnds lists the rules accepted by ParseRetailYearEnd.
+var RetailYearEnds = []RetailYearEnd{RetailYearEndLast, RetailYearEndNearest}
+
+// ParseRetailYearEnd parses a year-end rule name; "" is RetailYearEndLast.
+func ParseRetailYearEnd(name string) (RetailYearEnd, error) {
+	if name == "" {
+		return RetailYearEndLast, nil
+	}
+	for _, rule := range RetailYearEnds {
+		if strings.EqualFold(name, string(rule)) {
+			return rule, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownRetailYearEnd, name)
+}
+
+// RetailCalendar is a 52/53-week retail calendar: weeks run Sunday to
+// Saturday, the year ends on a Saturday around the end of January, and each
+// quarter is split into three periods following Pattern. The 53rd week of
+// long years is added to the last period.
+//
+// Retail years are named after the calendar year they start in: with
+// RetailYearEndNearest, retail year 2024 runs from 2024-02-04 to 2025-02-01.
+type RetailCalendar struct {
+	Pattern RetailPattern
+	YearEnd RetailYearEnd
+}
+
+func (r RetailCalendar) pattern() RetailPattern {
+	if r.Pattern == (RetailPattern{}) {
+		return Retail445
+	}
+	return r.Pattern
+}
+
+// yearEnd returns the last day of the retail year ending in January (or
+// early February) of the calendar year.
+func (r RetailCalendar) yearEnd(year int, loc *time.Location) time.Time {
+	jan31 := time.Date(year, time.January, 31, 0, 0, 0, 0, loc)
+	back := (int(jan31.Weekday()) - int(time.Saturday) + DaysInWeek) % DaysInWeek
+	if r.YearEnd == RetailYearEndNearest && back > DaysInWeek/2 {
+		return jan31.AddDate(0, 0, DaysInWeek-back)
+	}
+	return jan31.AddDate(0, 0, -back)
+}
+
+// Year returns the retail year containing t.
+func (r RetailCalendar) Year(t time.Time) int {
+	if daysSince(r.yearEnd(t.Year(), t.Location()), t) > 0 {
+		return t.Year()
+	}
+	return t.Year() - 1
+}
+
+// StartOfYear returns the start of the retail year containing t.
+func (r RetailCalendar) StartOfYear(t time.Time) time.Time {
+	return r.yearEnd(r.Year(t), t.Location()).AddDate(0, 0, 1)
+}
+
+// EndOfYear returns the end of the retail year containing t.
+func (r RetailCalendar) EndOfYear(t time.Time) time.Time {
+	return endOfDay(r.yearEnd(r.Year(t)+1, t.Location()), t.Location())
+}
+
+// Weeks returns the number of weeks (52 or 53) of the retail year containing t.
+func (r RetailCalendar) Weeks(t time.Time) int {
+	return daysSince(r.StartOfYear(t), r.EndOfYear(t))/DaysInWeek + 1
+}
+
+// Week returns the week of the retail year containing t, 1 to 53.
+func (r RetailCalendar) Week(t time.Time) int {
+	return daysSince(r.StartOfYear(t), t)/DaysInWeek + 1
+}
+
+// StartOfWeek returns the start of the retail week (Sunday) containing t.
+func (r RetailCalendar) StartOfWeek(t time.Time) time.Time {
+	return r.StartOfYear(t).AddDate(0, 0, (r.Week(t)-1)*DaysInWeek)
+}
+
+// EndOfWeek returns the end of the retail week (Saturday) containing t.
+func (r RetailCalendar) EndOfWeek(t time.Time) time.Time {
+	return endOfDay(r.StartOfWeek(t).AddDate(0, 0, DaysInWeek-1), t.Location())
+}
+
+// periodWeeks returns the number of weeks of the 12 periods of the retail
+// year containing t.
+func (r RetailCalendar) periodWeeks(t time.Time) [MonthsInYear]int {
+	var weeks [MonthsInYear]int
+	pattern := r.pattern()
+	for i := range weeks {
+		weeks[i] = pattern[i%len(pattern)]
+	}
+	if r.Weeks(t) > QuartersInYear*(pattern[0]+pattern[1]+pattern[2]) {
+		weeks[MonthsInYear-1]++
+	}
+	return weeks
+}
+
+// locatePeriod returns the period (1 to 12) containing t, with its first
+// week (1-based) and its length in weeks.
+func (r RetailCalendar) locatePeriod(t time.Time) (int, int, int) {
+	week := r.Week(t)
+	first := 1
+	weeks := r.periodWeeks(t)
+	for i, n := range weeks {
+		if week < first+n || i == len(weeks)-1 {
+			return i + 1, first, n
+		}
+		first += n
+	}
+	return 0, 0, 0
+}
+
+// Period returns the period of the retail year containing t, 1 to 12.
+func (r RetailCalendar) Period(t time.Time) int {
+	period, _, _ := r.locatePeriod(t)
+	return period
+}
+
+// StartOfPeriod returns the start of the retail period containing t.
+func (r RetailCalendar) StartOfPeriod(t time.Time) time.Time {
+	_, first, _ := r.locatePeriod(t)
+	return r.StartOfYear(t).AddDate(0, 0, (first-1)*DaysInWeek)
+}
+
+// EndOfPeriod returns the end of the retail period containing t.
+func (r RetailCalendar) EndOfPeriod(t time.Time) time.Time {
+	_, first, weeks := r.locatePeriod(t)
+	last := r.StartOfYear(t).AddDate(0, 0, (first-1+weeks)*DaysInWeek-1)
+	return endOfDay(last, t.Location())
+}
+
+// nextPeriodStart returns the start of the retail period following the one
+// containing t.
+func (r RetailCalendar) nextPeriodStart(t time.Time) time.Time {
+	return startOfDay(r.EndOfPeriod(t), t.Location()).AddDate(0, 0, 1)
+}
+
+// daysSince returns the number of calendar days from the date of from to the
+// date of t, ignoring clocks and DST.
+func daysSince(from, t time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / HoursInDay)
+}
+
+func applyRetailBoundaryOps(date time.Time, op string, retail RetailCalendar) (operationResult, bool) {
+	switch op {
+	case "startofperiod":
+		return operationResult{retail.StartOfPeriod(date), nil}, true
+	case "endofperiod":
+		return operationResult{retail.EndOfPeriod(date), nil}, true
+	default:
+		return operationResult{}, false
+	}
+}
+
+// retailIntervalPeriods returns the number of periods of a retail interval
+// such as "1rp". Retail periods cannot be combined with other units.
+func retailIntervalPeriods(components []relativeComponent) (int, bool, error) {
+	isRetail := false
+	for _, c := range components {
+		if c.unit == "rp" {
+			isRetail = true
+		}
+	}
+	if !isRetail {
+		return 0, false, nil
+	}
+	if len(components) != 1 {
+		return 0, true, fmt.Errorf("%w: retail periods cannot be combined", ErrInvalidInterval)
+	}
+
+	c := components[0]
+	if c.value < 1 || c.value != float64(int(c.value)) {
+		return 0, true, fmt.Errorf("%w: %grp", ErrInvalidInterval, c.value)
+	}
+	return int(c.value), true, nil
+}
@@ -0,0 +1,119 @@
+package calcdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetailCalendarYears(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 12, 0, 0, 0, time.UTC) }
+	nearest := RetailCalendar{YearEnd: RetailYearEndNearest}
+	last := RetailCalendar{}
+
+	tests := []struct {
+		name     string
+		retail   RetailCalendar
+		date     time.Time
+		year     int
+		start    string
+		end      string
+		weeks    int
+		expected int
+	}{
+		{"nearest, february", nearest, date(2024, 2, 10), 2024, "2024-02-04", "2025-02-01", 52, 1},
+		{"nearest, january", nearest, date(2025, 1, 15), 2024, "2024-02-04", "2025-02-01", 52, 50},
+		{"last, early february", last, date(2024, 2, 2), 2024, "2024-01-28", "2025-01-25", 52, 1},
+		{"last, 53 weeks", last, date(2026, 1, 31), 2025, "2025-01-26", "2026-01-31", 53, 53},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.year, tc.retail.Year(tc.date))
+			assert.Equal(t, tc.start+" 00:00:00", tc.retail.StartOfYear(tc.date).Format(time.DateTime))
+			assert.Equal(t, tc.end+" 23:59:59", tc.retail.EndOfYear(tc.date).Format(time.DateTime))
+			assert.Equal(t, tc.weeks, tc.retail.Weeks(tc.date))
+			assert.Equal(t, tc.expected, tc.retail.Week(tc.date))
+			assert.Equal(t, time.Sunday, tc.retail.StartOfWeek(tc.date).Weekday())
+			assert.Equal(t, time.Saturday, tc.retail.EndOfWeek(tc.date).Weekday())
+		})
+	}
+}
+
+func TestRetailCalendarPeriods(t *testing.T) {
+	tests := []struct {
+		name   string
+		retail RetailCalendar
+		date   time.Time
+		period int
+		start  string
+		end    string
+	}{
+		{"4-4-5 first period", RetailCalendar{}, time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC), 1, "2024-01-28", "2024-02-24"},
+		{"4-4-5 third period", RetailCalendar{}, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), 3, "2024-03-24", "2024-04-27"},
+		{"4-5-4 second period", RetailCalendar{Pattern: Retail454}, time.Date(2024, 3, 28, 0, 0, 0, 0, time.UTC), 2, "2024-02-25", "2024-03-30"},
+		{"5-4-4 first period", RetailCalendar{Pattern: Retail544}, time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC), 1, "2024-01-28", "2024-03-02"},
+		{"53rd week in last period", RetailCalendar{}, time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), 12, "2025-12-21", "2026-01-31"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.period, tc.retail.Period(tc.date))
+			assert.Equal(t, tc.start+" 00:00:00", tc.retail.StartOfPeriod(tc.date).Format(time.DateTime))
+			assert.Equal(t, tc.end+" 23:59:59", tc.retail.EndOfPeriod(tc.date).Format(time.DateTime))
+		})
+	}
+}
+
+func TestRetailOperations(t *testing.T) {
+	ctx := &EvalContext{Timezone: time.UTC, Retail: RetailCalendar{Pattern: Retail454, YearEnd: RetailYearEndNearest}}
+	for expr, expected := range map[string]string{
+		"2024-03-20 | startOfPeriod": "2024-03-03 00:00:00",
+		"2024-03-20 | endOfPeriod":   "2024-04-06 23:59:59",
+	} {
+		node, err := NewExprParser("").Parse(expr)
+		require.NoError(t, err)
+		result, err := node.Evaluate(ctx)
+		require.NoError(t, err, expr)
+		assert.Equal(t, expected, result.Format(time.DateTime), expr)
+	}
+}
+
+func TestRetailIteration(t *testing.T) {
+	start := time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	ctx := &EvalContext{Timezone: time.UTC}
+
+	results, err := IterateWithSpecialIntervalContext(start, end, "1rp", nil, ctx)
+	require.NoError(t, err)
+	ends := []string{}
+	for _, r := range results {
+		ends = append(ends, r.EndTime.Format(time.DateOnly))
+	}
+	assert.Equal(t, []string{"2024-02-25", "2024-03-24", "2024-04-28", "2024-05-26", "2024-06-01"}, ends)
+
+	results, err = IterateWithSpecialIntervalContext(start, end, "3rp", nil, ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-04-28", results[0].EndTime.Format(time.DateOnly))
+
+	_, err = IterateWithSpecialIntervalContext(start, end, "1rp2d", nil, ctx)
+	require.ErrorIs(t, err, ErrInvalidInterval)
+}
+
+func TestParseRetailCalendar(t *testing.T) {
+	pattern, err := ParseRetailPattern("5-4-4")
+	require.NoError(t, err)
+	assert.Equal(t, Retail544, pattern)
+
+	_, err = ParseRetailPattern("4-4-4")
+	require.ErrorIs(t, err, ErrUnknownRetailPattern)
+
+	rule, err := ParseRetailYearEnd("Nearest")
+	require.NoError(t, err)
+	assert.Equal(t, RetailYearEndNearest, rule)
+
+	_, err = ParseRetailYearEnd("first")
+	require.ErrorIs(t, err, ErrUnknownRetailYearEnd)
+}
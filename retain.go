@@ -0,0 +1,117 @@
+package calcdate
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// RetentionPolicy is a grandfather-father-son backup retention policy: the
+// number of hours, days, weeks, months and years, counted back from now
+// (the current one included), in which the newest snapshot is kept.
+type RetentionPolicy struct {
+	Hourly, Daily, Weekly, Monthly, Yearly int
+}
+
+// Validate rejects negative counts and a policy keeping nothing.
+func (p RetentionPolicy) Validate() error {
+	counts := []int{p.Hourly, p.Daily, p.Weekly, p.Monthly, p.Yearly}
+	for _, n := range counts {
+		if n < 0 {
+			return fmt.Errorf("%w: negative count %d", ErrInvalidRetentionPolicy, n)
+		}
+	}
+	if slices.Max(counts) == 0 {
+		return fmt.Errorf("%w: it keeps no snapshot", ErrInvalidRetentionPolicy)
+	}
+	return nil
+}
+
+// retentionRule buckets snapshots by period: start returns the start of the
+// period containing t, and back moves a period start n periods back.
+type retentionRule struct {
+	name   string
+	count  int
+	start  func(t time.Time) time.Time
+	back   func(t time.Time, n int) time.Time
+	layout string
+}
+
+func (p RetentionPolicy) rules(week Week) []retentionRule {
+	return []retentionRule{
+		{"hourly", p.Hourly,
+			func(t time.Time) time.Time { return startOfHour(t, t.Location()) },
+			func(t time.Time, n int) time.Time { return t.Add(-time.Duration(n) * time.Hour) },
+			"2006-01-02 15:00"},
+		{"daily", p.Daily,
+			func(t time.Time) time.Time { return startOfDay(t, t.Location()) },
+			func(t time.Time, n int) time.Time { return t.AddDate(0, 0, -n) },
+			time.DateOnly},
+		{"weekly", p.Weekly,
+			week.StartOf,
+			func(t time.Time, n int) time.Time { return t.AddDate(0, 0, -n*DaysInWeek) },
+			"week of 2006-01-02"},
+		{"monthly", p.Monthly,
+			func(t time.Time) time.Time { return startOfMonth(t, t.Location()) },
+			func(t time.Time, n int) time.Time { return t.AddDate(0, -n, 0) },
+			"2006-01"},
+		{"yearly", p.Yearly,
+			func(t time.Time) time.Time { return startOfYear(t, t.Location()) },
+			func(t time.Time, n int) time.Time { return t.AddDate(-n, 0, 0) },
+			"2006"},
+	}
+}
+
+// RetentionDecision tells whether a snapshot is kept, and by which rules:
+// "daily 2025-03-01", "monthly 2025-03"...
+type RetentionDecision struct {
+	Time    time.Time
+	Keep    bool
+	Reasons []string
+}
+
+// PlanRetention applies a retention policy to snapshots, returning one
+// decision per snapshot in the order given. Periods are those of now's
+// location, weeks start on week.Start; within each of the last N periods of
+// a rule, the newest snapshot is kept. Snapshots after now are kept.
+func PlanRetention(snapshots []time.Time, policy RetentionPolicy, now time.Time, week Week) []RetentionDecision {
+	loc := now.Location()
+	decisions := make([]RetentionDecision, len(snapshots))
+	newestFirst := make([]int, len(snapshots))
+	for i, snapshot := range snapshots {
+		decisions[i].Time = snapshot
+		newestFirst[i] = i
+		if snapshot.After(now) {
+			decisions[i].Keep = true
+			decisions[i].Reasons = []string{"after now"}
+		}
+	}
+	slices.SortStableFunc(newestFirst, func(a, b int) int {
+		return snapshots[b].Compare(snapshots[a])
+	})
+
+	for _, rule := range policy.rules(week) {
+		if rule.count <= 0 {
+			continue
+		}
+		oldest := rule.back(rule.start(now), rule.count-1)
+		kept := map[int64]bool{}
+		for _, i := range newestFirst {
+			t := snapshots[i].In(loc)
+			if t.After(now) {
+				continue
+			}
+			period := rule.start(t)
+			if period.Before(oldest) {
+				break
+			}
+			if kept[period.Unix()] {
+				continue
+			}
+			kept[period.Unix()] = true
+			decisions[i].Keep = true
+			decisions[i].Reasons = append(decisions[i].Reasons, rule.name+" "+period.Format(rule.layout))
+		}
+	}
+	return decisions
+}
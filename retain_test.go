@@ -0,0 +1,66 @@
+package calcdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetentionPolicyValidate(t *testing.T) {
+	require.NoError(t, RetentionPolicy{Daily: 7}.Validate())
+	require.ErrorIs(t, RetentionPolicy{}.Validate(), ErrInvalidRetentionPolicy)
+	require.ErrorIs(t, RetentionPolicy{Daily: 7, Weekly: -1}.Validate(), ErrInvalidRetentionPolicy)
+}
+
+func TestPlanRetention(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	// a snapshot every 6 hours for 100 days, newest first
+	snapshots := []time.Time{}
+	for i := range 400 {
+		snapshots = append(snapshots, time.Date(2025, 3, 1, 6, 0, 0, 0, time.UTC).Add(-time.Duration(6*i)*time.Hour))
+	}
+	snapshots = append(snapshots, now.Add(time.Hour))
+
+	policy := RetentionPolicy{Hourly: 7, Daily: 3, Weekly: 2, Monthly: 3, Yearly: 2}
+	decisions := PlanRetention(snapshots, policy, now, ISOWeek)
+	require.Len(t, decisions, len(snapshots))
+
+	kept := map[string][]string{}
+	for _, d := range decisions {
+		if d.Keep {
+			kept[d.Time.Format("2006-01-02 15:04")] = d.Reasons
+		}
+	}
+	assert.Equal(t, map[string][]string{
+		"2025-03-01 13:00": {"after now"},
+		"2025-03-01 06:00": {"hourly 2025-03-01 06:00", "daily 2025-03-01", "weekly week of 2025-02-24", "monthly 2025-03", "yearly 2025"},
+		"2025-02-28 18:00": {"daily 2025-02-28", "monthly 2025-02"},
+		"2025-02-27 18:00": {"daily 2025-02-27"},
+		"2025-02-23 18:00": {"weekly week of 2025-02-17"},
+		"2025-01-31 18:00": {"monthly 2025-01"},
+		"2024-12-31 18:00": {"yearly 2024"},
+	}, kept)
+}
+
+func TestPlanRetentionWeekStartAndZone(t *testing.T) {
+	tokyo := mustLoad(t, "Asia/Tokyo")
+	now := time.Date(2025, 3, 5, 12, 0, 0, 0, tokyo)
+	snapshots := []time.Time{
+		time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC), // Sunday 2 March in Tokyo
+		time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC), // Saturday 1 March in Tokyo
+	}
+
+	sunday := Week{Start: time.Sunday}
+	decisions := PlanRetention(snapshots, RetentionPolicy{Weekly: 2}, now, sunday)
+	assert.Equal(t, []string{"weekly week of 2025-03-02"}, decisions[0].Reasons)
+	assert.Equal(t, []string{"weekly week of 2025-02-23"}, decisions[1].Reasons)
+
+	// with Monday weeks, both fall in the week of 24 February
+	decisions = PlanRetention(snapshots, RetentionPolicy{Weekly: 1}, now, ISOWeek)
+	assert.False(t, decisions[0].Keep)
+	decisions = PlanRetention(snapshots, RetentionPolicy{Weekly: 2}, now, ISOWeek)
+	assert.Equal(t, []string{"weekly week of 2025-02-24"}, decisions[0].Reasons)
+	assert.False(t, decisions[1].Keep)
+}
@@ -0,0 +1,519 @@
+package calcdate
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RRuleFreq is the FREQ of a recurrence rule.
+type RRuleFreq string
+
+// Recurrence frequencies.
+const (
+	FreqYearly   RRuleFreq = "YEARLY"
+	FreqMonthly  RRuleFreq = "MONTHLY"
+	FreqWeekly   RRuleFreq = "WEEKLY"
+	FreqDaily    RRuleFreq = "DAILY"
+	FreqHourly   RRuleFreq = "HOURLY"
+	FreqMinutely RRuleFreq = "MINUTELY"
+)
+
+// RRuleFreqs lists the frequencies accepted by ParseRRule.
+var RRuleFreqs = []RRuleFreq{FreqYearly, FreqMonthly, FreqWeekly, FreqDaily, FreqHourly, FreqMinutely}
+
+// rruleWeekdays are the two-letter weekday codes of RFC 5545, Sunday first.
+var rruleWeekdays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// RRuleDay is a BYDAY entry: a weekday, optionally the Nth one of the month
+// or year ("2TU", "-1FR"). N is zero for every such weekday.
+type RRuleDay struct {
+	N       int
+	Weekday time.Weekday
+}
+
+// String returns the entry as in RFC 5545, e.g. "2TU".
+func (d RRuleDay) String() string {
+	if d.N == 0 {
+		return rruleWeekdays[d.Weekday]
+	}
+	return strconv.Itoa(d.N) + rruleWeekdays[d.Weekday]
+}
+
+// RRule is an RFC 5545 recurrence rule, with the EXDATE exclusions of the
+// event it belongs to. Occurrences are generated from a start (DTSTART) and
+// keep its wall clock; only start dates matching the rule are occurrences.
+type RRule struct {
+	Freq       RRuleFreq
+	Interval   int
+	ByDay      []RRuleDay
+	ByMonthDay []int
+	ByMonth    []time.Month
+	BySetPos   []int
+	// Until is the last possible occurrence (inclusive); zero means none.
+	Until time.Time
+	// Count limits the number of occurrences, EXDATEs included; zero means none.
+	Count int
+	// WeekStart is the WKST of weekly rules (Monday by default).
+	WeekStart time.Weekday
+	// ExDates are excluded occurrences. A date without a time of day
+	// (midnight, listed in exDays) excludes every occurrence on that day.
+	ExDates []time.Time
+	exDays  []bool
+}
+
+// ParseRRule parses a recurrence rule such as "FREQ=MONTHLY;BYDAY=2TU;COUNT=12".
+// The value may be prefixed with "RRULE:" and followed by "EXDATE:" lines
+// as copied from an iCalendar event. UNTIL and EXDATE values without a "Z"
+// suffix are read in loc, or in the zone of an EXDATE TZID parameter.
+func ParseRRule(value string, loc *time.Location) (*RRule, error) {
+	r := &RRule{Interval: 1, WeekStart: time.Monday}
+	hasFreq := false
+	for _, line := range strings.Fields(value) {
+		name, content, ok := strings.Cut(line, ":")
+		if !ok {
+			name, content = "RRULE", line
+		}
+		name, params, _ := strings.Cut(name, ";")
+		switch strings.ToUpper(name) {
+		case "RRULE":
+			if err := r.parseParts(content, loc); err != nil {
+				return nil, err
+			}
+			hasFreq = r.Freq != ""
+		case "EXDATE":
+			exLoc, err := paramLocation(params, loc)
+			if err != nil {
+				return nil, err
+			}
+			if err := r.AddExDates(content, exLoc); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("%w: unsupported property %s", ErrInvalidRRule, name)
+		}
+	}
+	if !hasFreq {
+		return nil, fmt.Errorf("%w: missing FREQ in %q", ErrInvalidRRule, value)
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL cannot be combined", ErrInvalidRRule)
+	}
+	return r, nil
+}
+
+func (r *RRule) parseParts(content string, loc *time.Location) error {
+	for _, part := range strings.Split(content, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return fmt.Errorf("%w: %q is not NAME=VALUE", ErrInvalidRRule, part)
+		}
+		if err := r.parsePart(strings.ToUpper(key), value, loc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//nolint:cyclop // one case per rule part
+func (r *RRule) parsePart(key, value string, loc *time.Location) error {
+	var err error
+	switch key {
+	case "FREQ":
+		r.Freq = RRuleFreq(strings.ToUpper(value))
+		if !slices.Contains(RRuleFreqs, r.Freq) {
+			return fmt.Errorf("%w: unsupported FREQ %s", ErrInvalidRRule, value)
+		}
+	case "INTERVAL":
+		r.Interval, err = strconv.Atoi(value)
+		if err != nil || r.Interval < 1 {
+			return fmt.Errorf("%w: INTERVAL=%s", ErrInvalidRRule, value)
+		}
+	case "COUNT":
+		r.Count, err = strconv.Atoi(value)
+		if err != nil || r.Count < 1 {
+			return fmt.Errorf("%w: COUNT=%s", ErrInvalidRRule, value)
+		}
+	case "UNTIL":
+		var dateOnly bool
+		r.Until, dateOnly, err = ParseICalDate(value, loc)
+		if err != nil {
+			return fmt.Errorf("%w: UNTIL=%s", ErrInvalidRRule, value)
+		}
+		if dateOnly {
+			r.Until = endOfDay(r.Until, loc)
+		}
+	case "BYDAY":
+		r.ByDay, err = parseRRuleDays(value)
+	case "BYMONTHDAY":
+		r.ByMonthDay, err = parseRRuleInts(key, value, 1, 31) //nolint:mnd // days of a month
+	case "BYMONTH":
+		var months []int
+		months, err = parseRRuleInts(key, value, 1, MonthsInYear)
+		for _, m := range months {
+			if m < 0 {
+				return fmt.Errorf("%w: BYMONTH=%s", ErrInvalidRRule, value)
+			}
+			r.ByMonth = append(r.ByMonth, time.Month(m))
+		}
+	case "BYSETPOS":
+		r.BySetPos, err = parseRRuleInts(key, value, 1, 366) //nolint:mnd // days of a year
+	case "WKST":
+		index := slices.Index(rruleWeekdays, strings.ToUpper(value))
+		if index < 0 {
+			return fmt.Errorf("%w: WKST=%s", ErrInvalidRRule, value)
+		}
+		r.WeekStart = time.Weekday(index)
+	default:
+		return fmt.Errorf("%w: unsupported part %s", ErrInvalidRRule, key)
+	}
+	return err
+}
+
+func parseRRuleDays(value string) ([]RRuleDay, error) {
+	days := []RRuleDay{}
+	for _, item := range strings.Split(strings.ToUpper(value), ",") {
+		const codeLength = 2
+		if len(item) < codeLength {
+			return nil, fmt.Errorf("%w: BYDAY=%s", ErrInvalidRRule, value)
+		}
+		weekday := slices.Index(rruleWeekdays, item[len(item)-codeLength:])
+		if weekday < 0 {
+			return nil, fmt.Errorf("%w: BYDAY=%s", ErrInvalidRRule, value)
+		}
+		day := RRuleDay{Weekday: time.Weekday(weekday)}
+		if prefix := item[:len(item)-codeLength]; prefix != "" {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, fmt.Errorf("%w: BYDAY=%s", ErrInvalidRRule, value)
+			}
+			day.N = n
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// parseRRuleInts parses a list of integers in [-high, -low] or [low, high].
+func parseRRuleInts(key, value string, low, high int) ([]int, error) {
+	values := []int{}
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil || n == 0 || n < -high || n > high || (n > 0 && n < low) {
+			return nil, fmt.Errorf("%w: %s=%s", ErrInvalidRRule, key, value)
+		}
+		values = append(values, n)
+	}
+	return values, nil
+}
+
+// AddExDates adds comma-separated exclusions, in iCalendar ("20250708",
+// "20250708T090000Z") or calcdate ("2025-07-08", "2025-07-08 09:00:00")
+// form.
+func (r *RRule) AddExDates(list string, loc *time.Location) error {
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		t, dateOnly, err := ParseICalDate(item, loc)
+		if err != nil {
+			return fmt.Errorf("%w: EXDATE %s", ErrInvalidRRule, item)
+		}
+		r.ExDates = append(r.ExDates, t)
+		r.exDays = append(r.exDays, dateOnly)
+	}
+	return nil
+}
+
+// paramLocation returns the zone of a TZID parameter in params
+// ("TZID=Europe/Paris;VALUE=DATE"), or loc without one.
+func paramLocation(params string, loc *time.Location) (*time.Location, error) {
+	for _, param := range strings.Split(params, ";") {
+		key, value, _ := strings.Cut(param, "=")
+		if strings.EqualFold(key, "TZID") {
+			return LoadTimezone(strings.Trim(value, `"`))
+		}
+	}
+	return loc, nil
+}
+
+// ParseICalDate parses an iCalendar DATE ("20250708") or DATE-TIME
+// ("20250708T090000", "20250708T090000Z"), or a calcdate date
+// ("2025-07-08", "2025-07-08 09:00:00"). Times without "Z" are read in loc.
+// dateOnly reports a value without a time of day.
+func ParseICalDate(value string, loc *time.Location) (time.Time, bool, error) {
+	layouts := []struct {
+		layout   string
+		dateOnly bool
+	}{
+		{"20060102T150405Z", false},
+		{"20060102T150405", false},
+		{"20060102", true},
+		{time.DateTime, false},
+		{"2006-01-02T15:04:05", false},
+		{time.DateOnly, true},
+	}
+	for _, l := range layouts {
+		var t time.Time
+		var err error
+		if strings.HasSuffix(l.layout, "Z") {
+			t, err = time.Parse(l.layout, value)
+		} else {
+			t, err = time.ParseInLocation(l.layout, value, loc)
+		}
+		if err == nil {
+			return t, l.dateOnly, nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("%w: %s", ErrInvalidDateValue, value)
+}
+
+// Between returns the occurrences of the rule starting at dtstart, up to end
+// (inclusive), without the EXDATEs.
+func (r *RRule) Between(dtstart, end time.Time) ([]time.Time, error) {
+	return r.between(dtstart, dtstart, end)
+}
+
+// between returns the occurrences from from to end (inclusive); earlier
+// occurrences still count towards COUNT.
+func (r *RRule) between(dtstart, from, end time.Time) ([]time.Time, error) {
+	occurrences := []time.Time{}
+	count := 0
+	for period := 0; ; period++ {
+		periodStart, candidates := r.period(dtstart, period)
+		if periodStart.After(end) || (!r.Until.IsZero() && periodStart.After(r.Until)) {
+			return occurrences, nil
+		}
+		for _, t := range candidates {
+			if t.Before(dtstart) {
+				continue
+			}
+			if t.After(end) || (!r.Until.IsZero() && t.After(r.Until)) || (r.Count > 0 && count >= r.Count) {
+				return occurrences, nil
+			}
+			count++
+			if !t.Before(from) && !r.isExcluded(t) {
+				occurrences = append(occurrences, t)
+			}
+			if len(occurrences) > MaxIterations {
+				return nil, ErrTooManyIterations
+			}
+		}
+	}
+}
+
+func (r *RRule) isExcluded(t time.Time) bool {
+	for i, ex := range r.ExDates {
+		if r.exDays[i] {
+			y, m, d := ex.Date()
+			ty, tm, td := t.Date()
+			if y == ty && m == tm && d == td {
+				return true
+			}
+		} else if ex.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// period returns the start of the period'th FREQ*INTERVAL period after
+// dtstart, and its occurrences in chronological order.
+func (r *RRule) period(dtstart time.Time, period int) (time.Time, []time.Time) {
+	loc := dtstart.Location()
+	step := period * r.Interval
+	at := func(day time.Time) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day(),
+			dtstart.Hour(), dtstart.Minute(), dtstart.Second(), dtstart.Nanosecond(), loc)
+	}
+
+	var start time.Time
+	var days []time.Time
+	switch r.Freq {
+	case FreqHourly, FreqMinutely:
+		unit := time.Hour
+		if r.Freq == FreqMinutely {
+			unit = time.Minute
+		}
+		t := dtstart.Add(time.Duration(step) * unit)
+		if r.matchesDay(t, true, true) {
+			return t, r.setPos([]time.Time{t})
+		}
+		return t, nil
+	case FreqDaily:
+		start = dtstart.AddDate(0, 0, step)
+		if r.matchesDay(start, true, true) {
+			days = []time.Time{start}
+		}
+	case FreqWeekly:
+		start = startOfWeek(dtstart, Week{Start: r.WeekStart}).AddDate(0, 0, step*DaysInWeek)
+		days = r.weekDays(start, dtstart)
+	case FreqMonthly:
+		start = time.Date(dtstart.Year(), dtstart.Month()+time.Month(step), 1, 0, 0, 0, 0, loc)
+		if len(r.ByMonth) == 0 || slices.Contains(r.ByMonth, start.Month()) {
+			days = r.monthDays(start, dtstart)
+		}
+	default:
+		start = time.Date(dtstart.Year()+step, time.January, 1, 0, 0, 0, 0, loc)
+		days = r.yearDays(start, dtstart)
+	}
+
+	occurrences := make([]time.Time, 0, len(days))
+	for _, day := range days {
+		occurrences = append(occurrences, at(day))
+	}
+	return start, r.setPos(occurrences)
+}
+
+func (r *RRule) weekDays(weekStart, dtstart time.Time) []time.Time {
+	days := []time.Time{}
+	for i := range DaysInWeek {
+		day := weekStart.AddDate(0, 0, i)
+		if len(r.ByDay) == 0 && day.Weekday() != dtstart.Weekday() {
+			continue
+		}
+		if r.matchesDay(day, true, false) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// monthDays returns the days of the month starting at first matching
+// BYMONTHDAY and BYDAY (Nth weekdays counted in the month), or the day of
+// month of dtstart without them.
+func (r *RRule) monthDays(first, dtstart time.Time) []time.Time {
+	days := []time.Time{}
+	last := DayInMonth(first.Year(), int(first.Month()))
+	for d := 1; d <= last; d++ {
+		day := first.AddDate(0, 0, d-1)
+		if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+			if d == dtstart.Day() {
+				days = append(days, day)
+			}
+			continue
+		}
+		if r.matchesMonthDay(d, last) && r.matchesWeekday(day, d, last) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+// yearDays returns the days of the year starting at first matching the
+// rule: BYDAY alone counts Nth weekdays in the year, otherwise the months of
+// BYMONTH (or every month, or the month of dtstart) are expanded as in
+// monthly rules.
+func (r *RRule) yearDays(first, dtstart time.Time) []time.Time {
+	if len(r.ByDay) > 0 && len(r.ByMonth) == 0 && len(r.ByMonthDay) == 0 {
+		days := []time.Time{}
+		total := daysSince(first, first.AddDate(1, 0, 0))
+		for i := range total {
+			day := first.AddDate(0, 0, i)
+			if r.matchesWeekday(day, i+1, total) {
+				days = append(days, day)
+			}
+		}
+		return days
+	}
+
+	months := r.ByMonth
+	if len(months) == 0 {
+		months = []time.Month{dtstart.Month()}
+		if len(r.ByMonthDay) > 0 || len(r.ByDay) > 0 {
+			months = []time.Month{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+		}
+	}
+	months = slices.Sorted(slices.Values(months))
+	days := []time.Time{}
+	for _, m := range months {
+		days = append(days, r.monthDays(time.Date(first.Year(), m, 1, 0, 0, 0, 0, first.Location()), dtstart)...)
+	}
+	return days
+}
+
+// matchesDay applies the BYMONTH, BYMONTHDAY and BYDAY filters of daily and
+// shorter rules (BYDAY without ordinals).
+func (r *RRule) matchesDay(t time.Time, byDay, byMonthDay bool) bool {
+	if len(r.ByMonth) > 0 && !slices.Contains(r.ByMonth, t.Month()) {
+		return false
+	}
+	last := DayInMonth(t.Year(), int(t.Month()))
+	if byMonthDay && !r.matchesMonthDay(t.Day(), last) {
+		return false
+	}
+	if byDay && len(r.ByDay) > 0 {
+		for _, d := range r.ByDay {
+			if d.Weekday == t.Weekday() {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func (r *RRule) matchesMonthDay(day, last int) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	for _, d := range r.ByMonthDay {
+		if d == day || (d < 0 && last+d+1 == day) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesWeekday reports whether the index'th day (1-based) of a month or
+// year of total days matches BYDAY, counting ordinals within it.
+func (r *RRule) matchesWeekday(t time.Time, index, total int) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, d := range r.ByDay {
+		if d.Weekday != t.Weekday() {
+			continue
+		}
+		nth := (index-1)/DaysInWeek + 1
+		nthFromEnd := -((total-index)/DaysInWeek + 1)
+		if d.N == 0 || d.N == nth || d.N == nthFromEnd {
+			return true
+		}
+	}
+	return false
+}
+
+// setPos keeps the BYSETPOS positions (1-based, negative from the end) of
+// the occurrences of one period.
+func (r *RRule) setPos(occurrences []time.Time) []time.Time {
+	if len(r.BySetPos) == 0 {
+		return occurrences
+	}
+	kept := []time.Time{}
+	for i, t := range occurrences {
+		for _, pos := range r.BySetPos {
+			if pos == i+1 || pos == i-len(occurrences) {
+				kept = append(kept, t)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// IterateRRule returns the occurrences of rule from start (DTSTART) to end
+// (inclusive) as rows whose begin and end are the occurrence. A transform
+// ("$begin, $begin +1h") reshapes each row.
+//
+//nolint:lll // long function signature is readable
+func IterateRRule(start, end time.Time, rule *RRule, transform *TransformNode, ctx *EvalContext) ([]IterationResult, error) {
+	occurrences, err := rule.Between(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return occurrenceRows(occurrences, transform, ctx)
+}
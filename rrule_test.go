@@ -0,0 +1,99 @@
+package calcdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRRuleBetween(t *testing.T) {
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	tests := []struct {
+		rule     string
+		expected []string
+	}{
+		{"FREQ=MONTHLY;BYDAY=2TU;COUNT=4", []string{"2025-01-14", "2025-02-11", "2025-03-11", "2025-04-08"}},
+		{"FREQ=MONTHLY;BYDAY=-1FR;COUNT=2", []string{"2025-01-31", "2025-02-28"}},
+		{"FREQ=MONTHLY;BYMONTHDAY=31;COUNT=3", []string{"2025-01-31", "2025-03-31", "2025-05-31"}},
+		{"FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=2", []string{"2025-01-31", "2025-02-28"}},
+		{"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;COUNT=3", []string{"2025-01-31", "2025-02-28", "2025-03-31"}},
+		{"FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13", []string{"2025-06-13"}},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;UNTIL=20250131", []string{"2025-01-03", "2025-01-13", "2025-01-17", "2025-01-27", "2025-01-31"}},
+		{"FREQ=WEEKLY;COUNT=3", []string{"2025-01-01", "2025-01-08", "2025-01-15"}},
+		{"FREQ=DAILY;BYDAY=SA,SU;UNTIL=20250112T100000Z", []string{"2025-01-04", "2025-01-05", "2025-01-11", "2025-01-12"}},
+		{"FREQ=YEARLY;BYMONTH=3,9;BYDAY=1MO", []string{"2025-03-03", "2025-09-01"}},
+		{"FREQ=YEARLY;BYDAY=20MO", []string{"2025-05-19"}},
+		{"FREQ=YEARLY;COUNT=2", []string{"2025-01-01"}},
+		{"RRULE:FREQ=MONTHLY;COUNT=3 EXDATE:20250201T100000Z", []string{"2025-01-01", "2025-03-01"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.rule, func(t *testing.T) {
+			rule, err := ParseRRule(tc.rule, time.UTC)
+			require.NoError(t, err)
+			occurrences, err := rule.Between(start, end)
+			require.NoError(t, err)
+			dates := []string{}
+			for _, o := range occurrences {
+				assert.Equal(t, "10:00:00", o.Format(time.TimeOnly))
+				dates = append(dates, o.Format(time.DateOnly))
+			}
+			assert.Equal(t, tc.expected, dates)
+		})
+	}
+}
+
+func TestRRuleHourly(t *testing.T) {
+	rule, err := ParseRRule("FREQ=HOURLY;INTERVAL=6;BYDAY=MO;COUNT=5", time.UTC)
+	require.NoError(t, err)
+	occurrences, err := rule.Between(time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC), time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, occurrences, 5)
+	assert.Equal(t, "2025-06-16 00:00:00", occurrences[0].Format(time.DateTime))
+	assert.Equal(t, "2025-06-23 00:00:00", occurrences[4].Format(time.DateTime))
+}
+
+func TestRRuleExDates(t *testing.T) {
+	paris := mustLoad(t, "Europe/Paris")
+	rule, err := ParseRRule("FREQ=DAILY;COUNT=5 EXDATE;TZID=Europe/Paris:20250602T090000", time.UTC)
+	require.NoError(t, err)
+	require.NoError(t, rule.AddExDates("2025-06-04", paris))
+
+	occurrences, err := rule.Between(time.Date(2025, 6, 1, 9, 0, 0, 0, paris), time.Date(2025, 7, 1, 0, 0, 0, 0, paris))
+	require.NoError(t, err)
+	dates := []string{}
+	for _, o := range occurrences {
+		dates = append(dates, o.Format(time.DateOnly))
+	}
+	assert.Equal(t, []string{"2025-06-01", "2025-06-03", "2025-06-05"}, dates)
+}
+
+func TestParseRRuleErrors(t *testing.T) {
+	for _, rule := range []string{
+		"", "BYDAY=MO", "FREQ=SECONDLY", "FREQ=DAILY;INTERVAL=0", "FREQ=DAILY;BYDAY=XX",
+		"FREQ=MONTHLY;BYMONTHDAY=32", "FREQ=DAILY;COUNT=2;UNTIL=20250101", "FREQ=DAILY;BYHOUR=9",
+		"FREQ=DAILY;UNTIL=tomorrow", "FREQ", "DTSTART:20250101",
+	} {
+		_, err := ParseRRule(rule, time.UTC)
+		require.ErrorIs(t, err, ErrInvalidRRule, rule)
+	}
+}
+
+func TestIterateRRule(t *testing.T) {
+	rule, err := ParseRRule("FREQ=WEEKLY;BYDAY=TU;COUNT=2", time.UTC)
+	require.NoError(t, err)
+	transform, err := NewExprParser("").ParseTransform("$begin | time 14:00, $begin | time 15:30")
+	require.NoError(t, err)
+
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	results, err := IterateRRule(start, start.AddDate(0, 1, 0), rule, transform, &EvalContext{Timezone: time.UTC})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "2025-06-03 14:00:00", results[0].BeginTime.Format(time.DateTime))
+	assert.Equal(t, "2025-06-10 15:30:00", results[1].EndTime.Format(time.DateTime))
+	assert.Equal(t, 1, results[1].Index)
+}
@@ -0,0 +1,122 @@
+package calcdate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// SQLDialect selects the timestamp literals of SQL predicates.
+type SQLDialect string
+
+// SQL dialects.
+const (
+	// DialectPostgres writes timestamptz literals with a UTC offset:
+	// '2025-01-01 00:00:00+01:00'::timestamptz.
+	DialectPostgres SQLDialect = "postgres"
+	// DialectMySQL writes DATETIME wall clocks: '2025-01-01 00:00:00'.
+	DialectMySQL SQLDialect = "mysql"
+	// DialectSQLite writes wall clocks comparable with its text dates: '2025-01-01 00:00:00'.
+	DialectSQLite SQLDialect = "sqlite"
+	// DialectClickHouse writes toDateTime('2025-01-01 00:00:00', 'Europe/Paris').
+	DialectClickHouse SQLDialect = "clickhouse"
+	// DialectBigQuery writes TIMESTAMP '2025-01-01 00:00:00+01:00'.
+	DialectBigQuery SQLDialect = "bigquery"
+)
+
+// SQLDialects lists the dialects accepted by ParseSQLDialect.
+var SQLDialects = []SQLDialect{DialectPostgres, DialectMySQL, DialectSQLite, DialectClickHouse, DialectBigQuery}
+
+// sqlDialectAliases maps alternative names to dialects.
+var sqlDialectAliases = map[string]SQLDialect{
+	"postgresql": DialectPostgres,
+	"pg":         DialectPostgres,
+	"mariadb":    DialectMySQL,
+	"sqlite3":    DialectSQLite,
+}
+
+// ParseSQLDialect parses a dialect name; "" is DialectPostgres.
+func ParseSQLDialect(name string) (SQLDialect, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return DialectPostgres, nil
+	}
+	for _, dialect := range SQLDialects {
+		if name == string(dialect) {
+			return dialect, nil
+		}
+	}
+	if dialect, ok := sqlDialectAliases[name]; ok {
+		return dialect, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownSQLDialect, name)
+}
+
+// SQLLiteral returns t as a timestamp literal of the dialect. Wall clocks
+// (MySQL, SQLite, ClickHouse) are those of t's location; fractional
+// seconds are kept to the microsecond.
+func SQLLiteral(t time.Time, dialect SQLDialect) string {
+	const (
+		wallLayout   = "2006-01-02 15:04:05.999999"
+		offsetLayout = "2006-01-02 15:04:05.999999-07:00"
+	)
+	switch dialect {
+	case DialectMySQL, DialectSQLite:
+		return "'" + t.Format(wallLayout) + "'"
+	case DialectClickHouse:
+		if t.Location().String() == "Local" {
+			// Local has no portable name
+			t = t.UTC()
+		}
+		if t.Nanosecond()/int(time.Microsecond) != 0 {
+			const precision = 6
+			return fmt.Sprintf("toDateTime64('%s', %d, '%s')", t.Format(wallLayout), precision, t.Location())
+		}
+		return fmt.Sprintf("toDateTime('%s', '%s')", t.Format(time.DateTime), t.Location())
+	case DialectBigQuery:
+		return "TIMESTAMP '" + t.Format(offsetLayout) + "'"
+	default:
+		return "'" + t.Format(offsetLayout) + "'::timestamptz"
+	}
+}
+
+// ValidateSQLColumn checks that column is an identifier, optionally qualified
+// by dots ("created_at", "orders.created_at"), since predicates write it as
+// is: letters, digits, '_' and '$', not starting with a digit.
+func ValidateSQLColumn(column string) error {
+	for _, part := range strings.Split(column, ".") {
+		if !isSQLIdentifier(part) {
+			return fmt.Errorf("%w: %q", ErrInvalidSQLColumn, column)
+		}
+	}
+	return nil
+}
+
+func isSQLIdentifier(name string) bool {
+	for i, ch := range name {
+		switch {
+		case ch == '_' || unicode.IsLetter(ch):
+		case i > 0 && (ch == '$' || unicode.IsDigit(ch)):
+		default:
+			return false
+		}
+	}
+	return name != ""
+}
+
+// SQLRangePredicate returns the half-open predicate matching column within
+// [begin, end): "column >= begin AND column < end". An end on the last
+// nanosecond of a second, as left by endOfDay or endOfMonth, is moved to the
+// next second so the predicate still covers that whole second.
+func SQLRangePredicate(column string, begin, end time.Time, dialect SQLDialect) string {
+	if end.Nanosecond() == int(time.Second-time.Nanosecond) {
+		end = end.Add(time.Nanosecond)
+	}
+	return fmt.Sprintf("%s >= %s AND %s < %s", column, SQLLiteral(begin, dialect), column, SQLLiteral(end, dialect))
+}
+
+// SQLDatePredicate returns the predicate matching column at t: "column = t".
+func SQLDatePredicate(column string, t time.Time, dialect SQLDialect) string {
+	return fmt.Sprintf("%s = %s", column, SQLLiteral(t, dialect))
+}
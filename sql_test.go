@@ -0,0 +1,78 @@
+package calcdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSQLDialect(t *testing.T) {
+	tests := map[string]SQLDialect{
+		"":           DialectPostgres,
+		"PostgreSQL": DialectPostgres,
+		"pg":         DialectPostgres,
+		"mysql":      DialectMySQL,
+		"mariadb":    DialectMySQL,
+		"sqlite":     DialectSQLite,
+		"clickhouse": DialectClickHouse,
+		"bigquery":   DialectBigQuery,
+	}
+	for name, expected := range tests {
+		dialect, err := ParseSQLDialect(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, expected, dialect, name)
+	}
+
+	_, err := ParseSQLDialect("oracle")
+	require.ErrorIs(t, err, ErrUnknownSQLDialect)
+}
+
+func TestSQLLiteral(t *testing.T) {
+	paris := mustLoad(t, "Europe/Paris")
+	at := time.Date(2025, 3, 1, 9, 30, 0, 0, paris)
+	fraction := at.Add(1500 * time.Microsecond)
+
+	tests := []struct {
+		dialect  SQLDialect
+		t        time.Time
+		expected string
+	}{
+		{DialectPostgres, at, "'2025-03-01 09:30:00+01:00'::timestamptz"},
+		{DialectPostgres, fraction, "'2025-03-01 09:30:00.0015+01:00'::timestamptz"},
+		{DialectMySQL, at, "'2025-03-01 09:30:00'"},
+		{DialectSQLite, at.UTC(), "'2025-03-01 08:30:00'"},
+		{DialectClickHouse, at, "toDateTime('2025-03-01 09:30:00', 'Europe/Paris')"},
+		{DialectClickHouse, fraction, "toDateTime64('2025-03-01 09:30:00.0015', 6, 'Europe/Paris')"},
+		{DialectClickHouse, at.UTC(), "toDateTime('2025-03-01 08:30:00', 'UTC')"},
+		{DialectBigQuery, at, "TIMESTAMP '2025-03-01 09:30:00+01:00'"},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, SQLLiteral(tc.t, tc.dialect), string(tc.dialect))
+	}
+}
+
+func TestSQLRangePredicate(t *testing.T) {
+	begin := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t,
+		"ts >= '2025-03-01 00:00:00' AND ts < '2025-03-08 00:00:00'",
+		SQLRangePredicate("ts", begin, begin.AddDate(0, 0, 7), DialectMySQL))
+
+	// an inclusive end such as endOfMonth becomes the next second
+	assert.Equal(t,
+		"ts >= TIMESTAMP '2025-03-01 00:00:00+00:00' AND ts < TIMESTAMP '2025-04-01 00:00:00+00:00'",
+		SQLRangePredicate("ts", begin, endOfMonth(begin, time.UTC), DialectBigQuery))
+
+	assert.Equal(t, "ts = '2025-03-01 00:00:00'", SQLDatePredicate("ts", begin, DialectSQLite))
+}
+
+func TestValidateSQLColumn(t *testing.T) {
+	for _, column := range []string{"created_at", "orders.created_at", "public.orders.ts", "_ts", "créé_le", "col$1"} {
+		require.NoError(t, ValidateSQLColumn(column), column)
+	}
+	for _, column := range []string{"", "1ts", "orders.", ".ts", "ts; DROP TABLE orders", "ts)", "\"ts\"", "a..b", "$ts"} {
+		require.ErrorIs(t, ValidateSQLColumn(column), ErrInvalidSQLColumn, column)
+	}
+}
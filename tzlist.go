@@ -0,0 +1,194 @@
+package calcdate
+
+import (
+	_ "embed" // embedded zone names
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// embeddedZoneNames lists the zones of the Go tzdata, one per line. It is
+// used when the system has no zoneinfo directory (distroless/scratch images).
+//
+//go:generate go run gen_zonenames.go
+//go:embed zonenames.txt
+var embeddedZoneNames string
+
+// zoneDirs maps GOOS to the system zoneinfo directory.
+var zoneDirs = map[string]string{
+	"android":   "/system/usr/share/zoneinfo/",
+	"darwin":    "/usr/share/zoneinfo/",
+	"dragonfly": "/usr/share/zoneinfo/",
+	"freebsd":   "/usr/share/zoneinfo/",
+	"linux":     "/usr/share/zoneinfo/",
+	"netbsd":    "/usr/share/zoneinfo/",
+	"openbsd":   "/usr/share/zoneinfo/",
+	"solaris":   "/usr/share/lib/zoneinfo/",
+}
+
+// ZoneInfo describes a timezone at a given instant.
+type ZoneInfo struct {
+	Name          string `json:"name"`
+	Offset        string `json:"offset"`
+	OffsetSeconds int    `json:"offsetSeconds"`
+	Abbreviation  string `json:"abbreviation"`
+	DST           bool   `json:"dst"`
+}
+
+// Region returns the first component of the zone name, e.g. "America" for
+// "America/New_York", or "" for names without a region like "UTC".
+func (z ZoneInfo) Region() string {
+	region, _, found := strings.Cut(z.Name, "/")
+	if !found {
+		return ""
+	}
+	return region
+}
+
+// ListTZ returns the available timezones, sorted by name, described at the
+// instant at. Zones come from the system zoneinfo directory, or from the list
+// embedded in the package when that directory is missing. Zones that cannot
+// be loaded are skipped: without a system zoneinfo directory, the program
+// must import time/tzdata (as cmd/calcdate does) for ListTZ to find any.
+func ListTZ(at time.Time) ([]ZoneInfo, error) {
+	names := systemZoneNames()
+	if len(names) == 0 {
+		names = strings.Fields(embeddedZoneNames)
+	}
+
+	zones := make([]ZoneInfo, 0, len(names))
+	for _, name := range names {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			continue
+		}
+		t := at.In(loc)
+		abbreviation, offset := t.Zone()
+		zones = append(zones, ZoneInfo{
+			Name:          name,
+			Offset:        FormatOffset(t),
+			OffsetSeconds: offset,
+			Abbreviation:  abbreviation,
+			DST:           t.IsDST(),
+		})
+	}
+	if len(zones) == 0 {
+		return nil, ErrNoTimezoneDatabase
+	}
+
+	sort.Slice(zones, func(i, j int) bool { return zones[i].Name < zones[j].Name })
+	return zones, nil
+}
+
+// systemZoneNames walks the system zoneinfo directory. Only paths whose
+// components start with a capital letter are zones ("posix", "right" and
+// the *.tab files are skipped).
+func systemZoneNames() []string {
+	root, ok := zoneDirs[runtime.GOOS]
+	if !ok {
+		return nil
+	}
+
+	names := []string{}
+	_ = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || path == root {
+			return nil //nolint:nilerr // unreadable entries are skipped
+		}
+		if !isZoneComponent(entry.Name()) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.IsDir() {
+			name, err := filepath.Rel(root, path)
+			if err == nil {
+				names = append(names, filepath.ToSlash(name))
+			}
+		}
+		return nil
+	})
+	return names
+}
+
+func isZoneComponent(name string) bool {
+	return name != "" && name[0] >= 'A' && name[0] <= 'Z' && !strings.Contains(name, ".")
+}
+
+// ZoneFilter selects zones by name substring, region and UTC offset.
+// Empty fields match every zone.
+type ZoneFilter struct {
+	Search string // case-insensitive substring of the name, e.g. "york"
+	Region string // first name component, e.g. "Europe"
+	Offset string // UTC offset, e.g. "+02:00", "+2" or "-0530"
+}
+
+// Filter returns the zones matching the filter.
+func (f ZoneFilter) Filter(zones []ZoneInfo) ([]ZoneInfo, error) {
+	offset, hasOffset := 0, f.Offset != ""
+	if hasOffset {
+		var err error
+		offset, err = ParseOffset(f.Offset)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	matches := []ZoneInfo{}
+	for _, z := range zones {
+		if f.Search != "" && !strings.Contains(strings.ToLower(z.Name), strings.ToLower(f.Search)) {
+			continue
+		}
+		if f.Region != "" && !strings.EqualFold(z.Region(), f.Region) {
+			continue
+		}
+		if hasOffset && z.OffsetSeconds != offset {
+			continue
+		}
+		matches = append(matches, z)
+	}
+	return matches, nil
+}
+
+// ParseOffset parses a UTC offset such as "+02:00", "-0530", "+2", "5:45"
+// or "UTC+1" into seconds east of UTC.
+func ParseOffset(value string) (int, error) {
+	s := strings.ToUpper(strings.TrimSpace(value))
+	if s == "UTC" || s == "GMT" || s == "Z" {
+		return 0, nil
+	}
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "UTC"), "GMT")
+
+	sign := 1
+	switch {
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	case strings.HasPrefix(s, "-"):
+		sign = -1
+		s = s[1:]
+	}
+
+	if s == "" || strings.ContainsAny(s, "+-") {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidOffset, value)
+	}
+
+	const hhmmLength = 4
+	hours, minutes := s, "0"
+	if h, m, found := strings.Cut(s, ":"); found {
+		hours, minutes = h, m
+	} else if len(s) == hhmmLength {
+		hours, minutes = s[:2], s[2:]
+	}
+
+	h, errH := strconv.Atoi(hours)
+	m, errM := strconv.Atoi(minutes)
+	if errH != nil || errM != nil || h > HoursInDay || m >= MinutesInHour {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidOffset, value)
+	}
+	return sign * (h*SecondsPerHour + m*SecondsPerMinute), nil
+}
@@ -0,0 +1,125 @@
+package calcdate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// WeekdaySet is a set of weekdays, such as the weekend days.
+type WeekdaySet uint8
+
+// NewWeekdaySet returns the set of the given days.
+func NewWeekdaySet(days ...time.Weekday) WeekdaySet {
+	var set WeekdaySet
+	for _, d := range days {
+		set |= 1 << d
+	}
+	return set
+}
+
+// Contains reports whether d is in the set.
+func (s WeekdaySet) Contains(d time.Weekday) bool {
+	return s&(1<<d) != 0
+}
+
+// Days returns the days of the set, Sunday first.
+func (s WeekdaySet) Days() []time.Weekday {
+	days := []time.Weekday{}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if s.Contains(d) {
+			days = append(days, d)
+		}
+	}
+	return days
+}
+
+// String returns the short English names of the days, e.g. "Sat,Sun", or
+// "none" for the empty set.
+func (s WeekdaySet) String() string {
+	days := s.Days()
+	if len(days) == 0 {
+		return "none"
+	}
+	names := make([]string, len(days))
+	for i, d := range days {
+		names[i] = LocaleEN.ShortWeekdays[d]
+	}
+	return strings.Join(names, ",")
+}
+
+// ParseWeekdaySet parses a comma-separated list of weekday names, in the
+// locale or in English ("fri,sat", "vendredi,samedi"). "none" is the empty set.
+func ParseWeekdaySet(list string, locale *Locale) (WeekdaySet, error) {
+	if strings.EqualFold(strings.TrimSpace(list), "none") {
+		return 0, nil
+	}
+	var set WeekdaySet
+	for _, name := range strings.Split(list, ",") {
+		d, err := ParseWeekStart(name, locale)
+		if err != nil {
+			return 0, err
+		}
+		set |= NewWeekdaySet(d)
+	}
+	return set, nil
+}
+
+// ParseWeekStart parses a weekday name, in the locale or in English.
+func ParseWeekStart(name string, locale *Locale) (time.Weekday, error) {
+	if locale == nil {
+		locale = LocaleEN
+	}
+	d, ok := locale.ParseWeekday(name)
+	if !ok {
+		return time.Sunday, fmt.Errorf("%w: %q", ErrInvalidWeekday, strings.TrimSpace(name))
+	}
+	return d, nil
+}
+
+// Week defines the first day of the week and the weekend days.
+type Week struct {
+	Start   time.Weekday
+	Weekend WeekdaySet
+}
+
+// Common weeks.
+var (
+	// ISOWeek starts on Monday with a Saturday-Sunday weekend. It is the default.
+	ISOWeek = Week{Start: time.Monday, Weekend: NewWeekdaySet(time.Saturday, time.Sunday)}
+	// USWeek starts on Sunday with a Saturday-Sunday weekend.
+	USWeek = Week{Start: time.Sunday, Weekend: NewWeekdaySet(time.Saturday, time.Sunday)}
+	// MiddleEastWeek starts on Sunday with a Friday-Saturday weekend.
+	MiddleEastWeek = Week{Start: time.Sunday, Weekend: NewWeekdaySet(time.Friday, time.Saturday)}
+)
+
+// StartOf returns the start of the week containing t.
+func (w Week) StartOf(t time.Time) time.Time {
+	return startOfWeek(t, w)
+}
+
+// EndOf returns the end of the week containing t.
+func (w Week) EndOf(t time.Time) time.Time {
+	return endOfWeek(t, w)
+}
+
+// IsWeekend reports whether t falls on a weekend day.
+func (w Week) IsWeekend(t time.Time) bool {
+	return w.Weekend.Contains(t.Weekday())
+}
+
+// IsWorkday reports whether t falls outside the weekend.
+func (w Week) IsWorkday(t time.Time) bool {
+	return !w.IsWeekend(t)
+}
+
+// String describes the week, e.g. "Mon-Sun, weekend Sat,Sun".
+func (w Week) String() string {
+	last := (w.Start + DaysInWeek - 1) % DaysInWeek
+	return fmt.Sprintf("%s-%s, weekend %s", LocaleEN.ShortWeekdays[w.Start], LocaleEN.ShortWeekdays[last], w.Weekend)
+}
+
+// week returns the week of the context, ISOWeek by default.
+func (ctx *EvalContext) week() Week {
+	return ctx.operationOptions().weekOrDefault()
+}
@@ -0,0 +1,113 @@
+package calcdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeekBoundaries(t *testing.T) {
+	wednesday := time.Date(2025, 6, 11, 15, 0, 0, 0, time.UTC)
+	sunday := time.Date(2025, 6, 15, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		week          Week
+		date          time.Time
+		expectedStart string
+		expectedEnd   string
+	}{
+		{"iso", ISOWeek, wednesday, "2025-06-09", "2025-06-15"},
+		{"iso on sunday", ISOWeek, sunday, "2025-06-09", "2025-06-15"},
+		{"us", USWeek, wednesday, "2025-06-08", "2025-06-14"},
+		{"us on sunday", USWeek, sunday, "2025-06-15", "2025-06-21"},
+		{"saturday start", Week{Start: time.Saturday}, wednesday, "2025-06-07", "2025-06-13"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			start := tc.week.StartOf(tc.date)
+			end := tc.week.EndOf(tc.date)
+			assert.Equal(t, tc.expectedStart+" 00:00:00", start.Format(time.DateTime))
+			assert.Equal(t, tc.expectedEnd+" 23:59:59", end.Format(time.DateTime))
+		})
+	}
+}
+
+func TestWeekOperations(t *testing.T) {
+	tests := []struct {
+		expr     string
+		week     *Week
+		expected string
+	}{
+		{"2025-06-11 | startOfWeek", nil, "2025-06-09"},
+		{"2025-06-11 | startOfWeek", &USWeek, "2025-06-08"},
+		{"2025-06-11 | endOfWeek", &MiddleEastWeek, "2025-06-14"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			node, err := NewExprParser("").Parse(tc.expr)
+			require.NoError(t, err)
+			result, err := node.Evaluate(&EvalContext{Timezone: time.UTC, Week: tc.week})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result.Format(time.DateOnly))
+		})
+	}
+}
+
+func TestWeekend(t *testing.T) {
+	friday := time.Date(2025, 6, 13, 12, 0, 0, 0, time.UTC)
+	sunday := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	assert.False(t, ISOWeek.IsWeekend(friday))
+	assert.True(t, ISOWeek.IsWeekend(sunday))
+	assert.True(t, MiddleEastWeek.IsWeekend(friday))
+	assert.True(t, MiddleEastWeek.IsWorkday(sunday))
+
+	weekend, err := ParsePredicate("isWeekend")
+	require.NoError(t, err)
+	assert.False(t, weekend.Match(friday, LocalTimeValid, nil))
+	assert.True(t, weekend.Match(friday, LocalTimeValid, &EvalContext{Week: &MiddleEastWeek}))
+}
+
+func TestParseWeekdaySet(t *testing.T) {
+	set, err := ParseWeekdaySet("fri, sat", nil)
+	require.NoError(t, err)
+	assert.Equal(t, MiddleEastWeek.Weekend, set)
+	assert.Equal(t, "Fri,Sat", set.String())
+
+	set, err = ParseWeekdaySet("samedi,dimanche", LocaleFR)
+	require.NoError(t, err)
+	assert.Equal(t, ISOWeek.Weekend, set)
+
+	set, err = ParseWeekdaySet("none", nil)
+	require.NoError(t, err)
+	assert.Empty(t, set.Days())
+
+	_, err = ParseWeekdaySet("fri,someday", nil)
+	require.ErrorIs(t, err, ErrInvalidWeekday)
+
+	start, err := ParseWeekStart("Sonntag", LocaleDE)
+	require.NoError(t, err)
+	assert.Equal(t, time.Sunday, start)
+	assert.Equal(t, "Sun-Sat, weekend Fri,Sat", MiddleEastWeek.String())
+}
+
+func TestRangeIteratorAlignedOnWeeks(t *testing.T) {
+	start := time.Date(2025, 6, 11, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	iterator := NewRangeIterator(start, end, DaysInWeek*HoursInDay*time.Hour, nil, time.UTC)
+	iterator.Week = &USWeek
+	results, err := iterator.Iterate()
+	require.NoError(t, err)
+
+	ends := []string{}
+	for _, r := range results {
+		ends = append(ends, r.EndTime.Format(time.DateOnly))
+	}
+	assert.Equal(t, []string{"2025-06-15", "2025-06-22", "2025-06-29", "2025-07-01"}, ends)
+}
@@ -0,0 +1,52 @@
+package calcdate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ParseZoneList parses a comma-separated list of IANA zone names such as
+// "Europe/Paris,America/New_York,Asia/Tokyo".
+func ParseZoneList(list string) ([]*time.Location, error) {
+	zones := []*time.Location{}
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		loc, err := LoadTimezone(name)
+		if err != nil {
+			return nil, err
+		}
+		zones = append(zones, loc)
+	}
+	if len(zones) == 0 {
+		return nil, fmt.Errorf("%w: empty zone list", ErrInvalidTimezone)
+	}
+	return zones, nil
+}
+
+// FormatOffset returns the UTC offset of t in its location, e.g. "+05:30" or "-08:00".
+func FormatOffset(t time.Time) string {
+	_, offset := t.Zone()
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	return fmt.Sprintf("%c%02d:%02d", sign, offset/SecondsPerHour, (offset%SecondsPerHour)/SecondsPerMinute)
+}
+
+// ZoneAbbreviation returns the abbreviation of loc at the instant t, e.g. "CEST".
+func ZoneAbbreviation(t time.Time, loc *time.Location) string {
+	name, _ := t.In(loc).Zone()
+	return name
+}
+
+// ZoneLabel describes loc at the instant t for column headers,
+// e.g. "Europe/Paris (+02:00 CEST)".
+func ZoneLabel(t time.Time, loc *time.Location) string {
+	t = t.In(loc)
+	return fmt.Sprintf("%s (%s %s)", loc.String(), FormatOffset(t), ZoneAbbreviation(t, loc))
+}
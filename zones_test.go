@@ -0,0 +1,119 @@
+package calcdate
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseZoneList(t *testing.T) {
+	zones, err := ParseZoneList("Europe/Paris, America/New_York,Asia/Tokyo")
+	require.NoError(t, err)
+	require.Len(t, zones, 3)
+	assert.Equal(t, "America/New_York", zones[1].String())
+
+	_, err = ParseZoneList("Europe/Paris,Nowhere/Zone")
+	require.ErrorIs(t, err, ErrInvalidTimezone)
+
+	_, err = ParseZoneList(" , ")
+	require.ErrorIs(t, err, ErrInvalidTimezone)
+}
+
+func TestZoneLabel(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	require.NoError(t, err)
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	summer := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	winter := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "Asia/Kolkata (+05:30 IST)", ZoneLabel(summer, kolkata))
+	assert.Equal(t, "Europe/Paris (+02:00 CEST)", ZoneLabel(summer, paris))
+	assert.Equal(t, "Europe/Paris (+01:00 CET)", ZoneLabel(winter, paris))
+	assert.Equal(t, "America/New_York (-05:00 EST)", ZoneLabel(winter, newYork))
+}
+
+func TestListTZ(t *testing.T) {
+	at := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	zones, err := ListTZ(at)
+	require.NoError(t, err)
+	require.NotEmpty(t, zones)
+
+	var paris *ZoneInfo
+	for i := range zones {
+		if zones[i].Name == "Europe/Paris" {
+			paris = &zones[i]
+		}
+	}
+	require.NotNil(t, paris)
+	assert.Equal(t, ZoneInfo{
+		Name: "Europe/Paris", Offset: "+02:00", OffsetSeconds: 7200, Abbreviation: "CEST", DST: true,
+	}, *paris)
+	assert.Equal(t, "Europe", paris.Region())
+
+	// The embedded fallback list holds loadable zone names
+	embedded := strings.Fields(embeddedZoneNames)
+	assert.Contains(t, embedded, "America/Argentina/Buenos_Aires")
+	for _, name := range embedded[:10] {
+		_, err := time.LoadLocation(name)
+		require.NoError(t, err, name)
+	}
+}
+
+func TestZoneFilter(t *testing.T) {
+	zones := []ZoneInfo{
+		{Name: "America/New_York", OffsetSeconds: -4 * SecondsPerHour},
+		{Name: "Asia/Kolkata", OffsetSeconds: 5*SecondsPerHour + 30*SecondsPerMinute},
+		{Name: "Europe/Paris", OffsetSeconds: 2 * SecondsPerHour},
+		{Name: "Europe/London", OffsetSeconds: 1 * SecondsPerHour},
+		{Name: "UTC"},
+	}
+
+	names := func(filter ZoneFilter) []string {
+		matches, err := filter.Filter(zones)
+		require.NoError(t, err)
+		result := []string{}
+		for _, z := range matches {
+			result = append(result, z.Name)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"America/New_York"}, names(ZoneFilter{Search: "YORK"}))
+	assert.Equal(t, []string{"Europe/Paris", "Europe/London"}, names(ZoneFilter{Region: "europe"}))
+	assert.Equal(t, []string{"Europe/London"}, names(ZoneFilter{Region: "Europe", Offset: "+1"}))
+	assert.Equal(t, []string{"Asia/Kolkata"}, names(ZoneFilter{Offset: "+0530"}))
+	assert.Equal(t, []string{"UTC"}, names(ZoneFilter{Offset: "UTC"}))
+	assert.Len(t, names(ZoneFilter{}), len(zones))
+
+	_, err := ZoneFilter{Offset: "abc"}.Filter(zones)
+	require.ErrorIs(t, err, ErrInvalidOffset)
+}
+
+func TestParseOffset(t *testing.T) {
+	testCases := map[string]int{
+		"+02:00": 2 * SecondsPerHour,
+		"+2":     2 * SecondsPerHour,
+		"-0530":  -(5*SecondsPerHour + 30*SecondsPerMinute),
+		"5:45":   5*SecondsPerHour + 45*SecondsPerMinute,
+		"UTC+1":  SecondsPerHour,
+		"GMT-3":  -3 * SecondsPerHour,
+		"Z":      0,
+	}
+	for input, expected := range testCases {
+		offset, err := ParseOffset(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, expected, offset, input)
+	}
+
+	for _, input := range []string{"", "+", "abc", "+25", "+02:60", "+-2"} {
+		_, err := ParseOffset(input)
+		require.ErrorIs(t, err, ErrInvalidOffset, input)
+	}
+}